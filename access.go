@@ -0,0 +1,34 @@
+package reflecthelper
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// exposeValue makes the val obtained from unexported struct fields readable and settable using unsafe.
+// The val is returned as is if it is already accessible or if it is not addressable.
+func exposeValue(val reflect.Value) reflect.Value {
+	if !val.IsValid() || val.CanInterface() || !val.CanAddr() {
+		return val
+	}
+	return reflect.NewAt(val.Type(), unsafe.Pointer(val.UnsafeAddr())).Elem()
+}
+
+// exposeValueOpt is exposeValue that is only applied if the UnexportedAccess option is enabled.
+func exposeValueOpt(val reflect.Value, opt *Option) reflect.Value {
+	if opt == nil || !opt.UnexportedAccess {
+		return val
+	}
+	return exposeValue(val)
+}
+
+// getAddressableStruct copies the struct val to a new addressable value so that its unexported fields can be exposed.
+func getAddressableStruct(val reflect.Value, opt *Option) reflect.Value {
+	if !opt.UnexportedAccess || GetKind(val) != reflect.Struct || val.CanAddr() || !val.CanInterface() {
+		return val
+	}
+
+	res := reflect.New(val.Type()).Elem()
+	res.Set(val)
+	return res
+}
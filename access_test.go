@@ -0,0 +1,132 @@
+package reflecthelper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type accessInner struct {
+	value int
+}
+
+type accessStruct struct {
+	Public  string
+	name    string
+	count   int
+	timeout time.Duration
+	inner   *accessInner
+	tags    []string
+}
+
+func TestWithUnexportedAccess(t *testing.T) {
+	t.Run("assign reflect", func(t *testing.T) {
+		var res accessStruct
+		val := reflect.ValueOf(&res).Elem()
+
+		err := AssignReflect(val.FieldByName("name"), reflect.ValueOf("private"))
+		assert.Equal(t, ErrAssignerCantSet, err)
+
+		err = AssignReflect(val.FieldByName("name"), reflect.ValueOf("private"), WithUnexportedAccess(true))
+		assert.Nil(t, err)
+		err = AssignReflect(val.FieldByName("count"), reflect.ValueOf("10"), WithUnexportedAccess(true))
+		assert.Nil(t, err)
+		err = AssignReflect(val.FieldByName("timeout"), reflect.ValueOf("1m"), WithUnexportedAccess(true))
+		assert.Nil(t, err)
+		err = AssignReflect(val.FieldByName("inner"), reflect.ValueOf(map[string]interface{}{}), WithUnexportedAccess(true))
+		assert.Nil(t, err)
+		err = AssignReflect(val.FieldByName("tags"), reflect.ValueOf([]string{"a"}), WithUnexportedAccess(true))
+		assert.Nil(t, err)
+		assert.Equal(t, accessStruct{name: "private", count: 10, timeout: time.Minute, inner: &accessInner{}, tags: []string{"a"}}, res)
+
+		var copied accessStruct
+		err = AssignReflect(reflect.ValueOf(&copied).Elem().FieldByName("count"), val.FieldByName("count"), WithUnexportedAccess(true))
+		assert.Nil(t, err)
+		assert.Equal(t, 10, copied.count)
+	})
+
+	t.Run("extractors", func(t *testing.T) {
+		res := accessStruct{name: "private", count: 10, timeout: time.Second}
+		val := reflect.ValueOf(&res).Elem()
+
+		_, err := ExtractString(val.FieldByName("name"))
+		assert.NotNil(t, err)
+
+		str, err := ExtractString(val.FieldByName("name"), WithUnexportedAccess(true))
+		assert.Nil(t, err)
+		assert.Equal(t, "private", str)
+		assert.Equal(t, int64(10), GetInt(val.FieldByName("count"), WithUnexportedAccess(true)))
+		assert.Equal(t, time.Second, GetDuration(val.FieldByName("timeout"), WithUnexportedAccess(true)))
+		assert.Equal(t, int64(10), TryGet(val.FieldByName("count"), WithUnexportedAccess(true)))
+
+		_, err = ExtractString(reflect.ValueOf(res).FieldByName("name"), WithUnexportedAccess(true))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("clone with", func(t *testing.T) {
+		src := accessStruct{Public: "public", name: "private", inner: &accessInner{value: 1}, tags: []string{"a"}}
+
+		shared := CloneInterfaceWith(src).(accessStruct)
+		assert.Same(t, src.inner, shared.inner)
+
+		res := CloneInterfaceWith(src, WithUnexportedAccess(true)).(accessStruct)
+		assert.Equal(t, src, res)
+		assert.NotSame(t, src.inner, res.inner)
+		res.inner.value = 2
+		res.tags[0] = "b"
+		assert.Equal(t, 1, src.inner.value)
+		assert.Equal(t, "a", src.tags[0])
+	})
+
+	t.Run("walk", func(t *testing.T) {
+		src := accessStruct{name: "private", inner: &accessInner{value: 1}}
+		var paths []string
+		visitor := VisitorFunc(func(node *WalkNode) error {
+			if node.Value.CanInterface() && node.Field != nil {
+				paths = append(paths, node.Path.String())
+			}
+			return nil
+		})
+
+		assert.Nil(t, Walk(reflect.ValueOf(src), visitor))
+		assert.Equal(t, []string{"Public"}, paths)
+
+		paths = nil
+		assert.Nil(t, Walk(reflect.ValueOf(src), visitor, WithUnexportedAccess(true)))
+		assert.Equal(t, []string{"Public", "name", "count", "timeout", "inner", "inner.value", "tags"}, paths)
+	})
+
+	t.Run("struct iterations", func(t *testing.T) {
+		src := accessStruct{name: "private", count: 3}
+		var values []interface{}
+		val := Cast(reflect.ValueOf(src), WithUnexportedAccess(true))
+		err := val.IterateStruct(func(parent reflect.Value, field reflect.Value) error {
+			values = append(values, field.Interface())
+			return nil
+		}).Error()
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"", "private", 3, time.Duration(0), (*accessInner)(nil), []string(nil)}, values)
+		assert.False(t, val.CanAddr())
+
+		var parents []reflect.Value
+		err = val.IterateStructFields(func(parent reflect.Value, info FieldInfo) error {
+			parents = append(parents, parent)
+			return nil
+		}).Error()
+		assert.Nil(t, err)
+		assert.False(t, val.CanAddr())
+		assert.Equal(t, src, val.Interface())
+		assert.True(t, parents[0].CanAddr())
+
+		values = nil
+		val = Cast(reflect.ValueOf(&src), WithUnexportedAccess(true))
+		err = val.IterateStructFields(func(parent reflect.Value, info FieldInfo) error {
+			values = append(values, info.Value.Interface())
+			return nil
+		}).Error()
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"", "private", 3, time.Duration(0), (*accessInner)(nil), []string(nil)}, values)
+	})
+}
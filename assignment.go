@@ -4,6 +4,7 @@ import (
 	"net"
 	"net/url"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/fairyhunter13/task/v2"
@@ -11,6 +12,26 @@ import (
 )
 
 func assignReflect(assigner reflect.Value, val reflect.Value, opt *Option) (err error) {
+	assigner = exposeValueOpt(assigner, opt)
+	val = exposeValueOpt(val, opt)
+
+	// The *time.Location is assigned as is to keep its identity, e.g. time.UTC,
+	// and the shared location like time.UTC must not be overwritten.
+	if locAssigner := getLocationAssigner(assigner); locAssigner.IsValid() {
+		if !locAssigner.CanSet() {
+			err = ErrAssignerCantSet
+			return
+		}
+
+		var loc *time.Location
+		loc, err = extractLocation(GetChildElem(val), opt.resetCheck())
+		if err != nil {
+			return
+		}
+		locAssigner.Set(reflect.ValueOf(loc))
+		return
+	}
+
 	var oriAssigner reflect.Value
 	if assigner.CanSet() {
 		oriAssigner = assigner
@@ -22,6 +43,11 @@ func assignReflect(assigner reflect.Value, val reflect.Value, opt *Option) (err
 	if err != nil {
 		return
 	}
+	// The time.Location is never copied by value because it may be a shared location pointed by a *time.Location.
+	if GetType(oriAssigner) == TypeLocation {
+		err = ErrAssignerCantSet
+		return
+	}
 
 	val = GetChildElem(val)
 	err = checkExtractValid(val, opt.resetCheck())
@@ -40,6 +66,22 @@ func assignReflect(assigner reflect.Value, val reflect.Value, opt *Option) (err
 	return
 }
 
+// getLocationAssigner returns the first *time.Location from the pointer chain of the assigner.
+// Only the settable *time.Location can be assigned.
+func getLocationAssigner(assigner reflect.Value) (res reflect.Value) {
+	for assigner.IsValid() {
+		if GetType(assigner) == TypeLocationPtr {
+			res = assigner
+			return
+		}
+		if !IsValueElemable(assigner) || IsValueNil(assigner) {
+			return
+		}
+		assigner = assigner.Elem()
+	}
+	return
+}
+
 func tryAssign(assigner reflect.Value, val reflect.Value, opt *Option) (err error) {
 	defer recoverFnOpt(&err, opt)
 
@@ -80,8 +122,8 @@ func tryAssign(assigner reflect.Value, val reflect.Value, opt *Option) (err erro
 			if err != nil {
 				return
 			}
-			if assigner.OverflowInt(result) {
-				err = getErrOverflow(assigner)
+			result, err = handleIntOverflow(assigner, val, result, opt)
+			if err != nil {
 				return
 			}
 			assigner.SetInt(result)
@@ -93,8 +135,8 @@ func tryAssign(assigner reflect.Value, val reflect.Value, opt *Option) (err erro
 		if err != nil {
 			return
 		}
-		if assigner.OverflowUint(result) {
-			err = getErrOverflow(assigner)
+		result, err = handleUintOverflow(assigner, val, result, opt)
+		if err != nil {
 			return
 		}
 		assigner.SetUint(result)
@@ -105,8 +147,8 @@ func tryAssign(assigner reflect.Value, val reflect.Value, opt *Option) (err erro
 		if err != nil {
 			return
 		}
-		if assigner.OverflowFloat(result) {
-			err = getErrOverflow(assigner)
+		result, err = handleFloatOverflow(assigner, val, result, opt)
+		if err != nil {
 			return
 		}
 		assigner.SetFloat(result)
@@ -117,8 +159,8 @@ func tryAssign(assigner reflect.Value, val reflect.Value, opt *Option) (err erro
 		if err != nil {
 			return
 		}
-		if assigner.OverflowComplex(result) {
-			err = getErrOverflow(assigner)
+		result, err = handleComplexOverflow(assigner, val, result, opt)
+		if err != nil {
 			return
 		}
 		assigner.SetComplex(result)
@@ -191,6 +233,9 @@ func tryAssign(assigner reflect.Value, val reflect.Value, opt *Option) (err erro
 				return
 			}
 			assigner.Set(reflect.ValueOf(timeRes))
+		case TypeLocation:
+			// The time.Location is never copied by value, use *time.Location instead.
+			err = ErrAssignerCantSet
 		case TypeURL:
 			var urlRes *url.URL
 			urlRes, err = extractURL(val, opt)
@@ -255,7 +300,9 @@ func iterateAndAssign(assigner reflect.Value, val reflect.Value, isSlice bool, o
 		opt := opt.Clone()
 		index := index
 		tm.Run(func() (err error) {
+			defer RecoverFn(&err)
 			err = assignReflect(emptyList.Index(index), val.Index(index), opt)
+			err = setErrorPath(err, "["+strconv.Itoa(index)+"]")
 			return
 		})
 	}
@@ -272,7 +319,13 @@ func iterateAndAssignString(assigner reflect.Value, val reflect.Value, isSlice b
 	var listVal reflect.Value
 	switch GetKindElem(assigner) {
 	case reflect.Uint8:
-		listVal = reflect.ValueOf([]byte(val.String()))
+		var bytesVal []byte
+		bytesVal, err = decodeBytes(val.String(), opt)
+		if err != nil {
+			err = getErrParse(val, TypeByteSlice, err)
+			return
+		}
+		listVal = reflect.ValueOf(bytesVal)
 	case reflect.Int32:
 		listVal = reflect.ValueOf([]rune(val.String()))
 	default:
@@ -8,6 +8,7 @@ import (
 
 // Clone clones the current underlying value of val as a reflect.Value.
 // Clone can't clone unexported struct field because it is inaccessible.
+// Use CloneWith with WithUnexportedAccess to clone the unexported struct fields.
 func Clone(val reflect.Value) (res reflect.Value) {
 	if !val.IsValid() {
 		return
@@ -26,6 +27,30 @@ func CloneInterface(input interface{}) (res interface{}) {
 	return
 }
 
+// CloneWith clones the underlying value of val selectively using the function options.
+// The depth limit, the shallow types, and the skipped paths are set by
+// WithCloneMaxDepth, WithCloneShallowTypes, and WithCloneSkipPaths.
+// Struct fields with the tag `clone:"-"` are zeroed in the result.
+// Unexported struct fields are shared with the source instead of being copied,
+// unless WithUnexportedAccess is used.
+func CloneWith(val reflect.Value, fnOpts ...FuncOption) (res reflect.Value) {
+	if !val.IsValid() {
+		return
+	}
+
+	res = newCloner(NewOption().Assign(fnOpts...)).clone(val, "", 0)
+	return
+}
+
+// CloneInterfaceWith is like CloneWith but it accepts input and returns output as interface{}.
+func CloneInterfaceWith(input interface{}, fnOpts ...FuncOption) (res interface{}) {
+	val := CloneWith(getValFromInterface(input), fnOpts...)
+	if val.IsValid() && val.CanInterface() {
+		res = val.Interface()
+	}
+	return
+}
+
 // InitNew initializes a new reflect.Value with reflect.Type of val.
 func InitNew(val reflect.Value) (res reflect.Value) {
 	if !val.IsValid() {
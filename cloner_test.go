@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -71,3 +72,107 @@ func TestCloneInterface(t *testing.T) {
 		fmt.Println(s, b)
 	})
 }
+
+type cloneDB struct {
+	name string
+}
+
+type cloneAddress struct {
+	City   string
+	Street *string
+}
+
+type cloneRequest struct {
+	ID       int
+	DB       *cloneDB
+	Address  *cloneAddress
+	Tags     []string
+	Meta     map[string]*cloneAddress
+	Password string `clone:"-"`
+	Token    string
+	Next     *cloneRequest
+	Created  time.Time
+	secret   string
+}
+
+func TestCloneWith(t *testing.T) {
+	newRequest := func() *cloneRequest {
+		street := "Main Street"
+		return &cloneRequest{
+			ID:       1,
+			DB:       &cloneDB{name: "primary"},
+			Address:  &cloneAddress{City: "Jakarta", Street: &street},
+			Tags:     []string{"a", "b"},
+			Meta:     map[string]*cloneAddress{"home": {City: "Bandung"}},
+			Password: "secret",
+			Token:    "token",
+			Created:  time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+			secret:   "unexported",
+		}
+	}
+	t.Run("invalid value", func(t *testing.T) {
+		assert.False(t, CloneWith(reflect.ValueOf(nil)).IsValid())
+		assert.Nil(t, CloneInterfaceWith(nil))
+	})
+	t.Run("deep copy by default", func(t *testing.T) {
+		req := newRequest()
+		res := CloneInterfaceWith(req).(*cloneRequest)
+
+		assert.NotSame(t, req, res)
+		assert.NotSame(t, req.DB, res.DB)
+		assert.NotSame(t, req.Address, res.Address)
+		assert.NotSame(t, req.Address.Street, res.Address.Street)
+		assert.NotSame(t, req.Meta["home"], res.Meta["home"])
+		assert.Equal(t, req.Address, res.Address)
+		assert.Equal(t, req.Created, res.Created)
+		assert.Equal(t, req.secret, res.secret)
+		assert.Equal(t, "", res.Password)
+		assert.Equal(t, "token", res.Token)
+
+		res.Tags[0] = "changed"
+		assert.Equal(t, "a", req.Tags[0])
+	})
+	t.Run("shallow types", func(t *testing.T) {
+		req := newRequest()
+		res := CloneInterfaceWith(req, WithCloneShallowTypes(reflect.TypeOf(cloneDB{}))).(*cloneRequest)
+		assert.Same(t, req.DB, res.DB)
+		assert.NotSame(t, req.Address, res.Address)
+
+		res = CloneInterfaceWith(req, WithCloneShallowTypes(reflect.TypeOf(&cloneAddress{}))).(*cloneRequest)
+		assert.NotSame(t, req.DB, res.DB)
+		assert.Same(t, req.Address, res.Address)
+	})
+	t.Run("skip paths", func(t *testing.T) {
+		req := newRequest()
+		res := CloneInterfaceWith(req, WithCloneSkipPaths("Token", "Address.City", "Meta.City")).(*cloneRequest)
+		assert.Equal(t, "", res.Token)
+		assert.Equal(t, "", res.Address.City)
+		assert.Equal(t, "Main Street", *res.Address.Street)
+		assert.Equal(t, "", res.Meta["home"].City)
+		assert.Equal(t, "Jakarta", req.Address.City)
+	})
+	t.Run("max depth", func(t *testing.T) {
+		req := newRequest()
+		res := CloneInterfaceWith(req, WithCloneMaxDepth(1)).(*cloneRequest)
+		assert.NotSame(t, req, res)
+		assert.Same(t, req.DB, res.DB)
+		assert.Same(t, req.Address, res.Address)
+
+		res = CloneInterfaceWith(req, WithCloneMaxDepth(2)).(*cloneRequest)
+		assert.NotSame(t, req.Address, res.Address)
+		assert.Same(t, req.Address.Street, res.Address.Street)
+	})
+	t.Run("cyclic pointer", func(t *testing.T) {
+		req := newRequest()
+		req.Next = req
+		res := CloneInterfaceWith(req).(*cloneRequest)
+		assert.NotSame(t, req, res)
+		assert.Same(t, res, res.Next)
+	})
+	t.Run("non pointer value", func(t *testing.T) {
+		req := *newRequest()
+		res := CloneWith(reflect.ValueOf(req)).Interface().(cloneRequest)
+		assert.NotSame(t, req.Address, res.Address)
+		assert.Equal(t, req.ID, res.ID)
+	})
+}
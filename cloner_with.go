@@ -0,0 +1,153 @@
+package reflecthelper
+
+import "reflect"
+
+// TagClone is the struct tag key read by CloneWith.
+const TagClone = "clone"
+
+type cloner struct {
+	opt     *Option
+	shallow map[reflect.Type]struct{}
+	skip    map[string]struct{}
+	visited map[valueVisit]reflect.Value
+}
+
+func newCloner(opt *Option) (c *cloner) {
+	c = &cloner{
+		opt:     opt,
+		shallow: make(map[reflect.Type]struct{}, len(opt.CloneShallowTypes)),
+		skip:    make(map[string]struct{}, len(opt.CloneSkipPaths)),
+		visited: make(map[valueVisit]reflect.Value),
+	}
+	for _, typ := range opt.CloneShallowTypes {
+		if typ != nil {
+			c.shallow[typ] = struct{}{}
+		}
+	}
+	for _, path := range opt.CloneSkipPaths {
+		c.skip[path] = struct{}{}
+	}
+	return
+}
+
+func (c *cloner) isShallow(typ reflect.Type) (res bool) {
+	if _, res = c.shallow[typ]; res {
+		return
+	}
+	if typ.Kind() == reflect.Ptr {
+		_, res = c.shallow[typ.Elem()]
+	}
+	return
+}
+
+func (c *cloner) isSkipped(field reflect.StructField, path string) (res bool) {
+	if field.Tag.Get(TagClone) == "-" {
+		res = true
+		return
+	}
+	_, res = c.skip[path]
+	return
+}
+
+func (c *cloner) isDepthExceeded(depth int) bool {
+	return c.opt.CloneMaxDepth > 0 && depth >= c.opt.CloneMaxDepth
+}
+
+func (c *cloner) clone(val reflect.Value, path string, depth int) (res reflect.Value) {
+	res = val
+	typ := val.Type()
+	if c.isShallow(typ) || c.isDepthExceeded(depth) {
+		return
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr:
+		if val.IsNil() {
+			return
+		}
+		visit := valueVisit{val.Pointer(), typ}
+		if visited, ok := c.visited[visit]; ok {
+			res = visited
+			return
+		}
+		res = reflect.New(typ.Elem())
+		c.visited[visit] = res
+		res.Elem().Set(c.clone(val.Elem(), path, depth))
+	case reflect.Interface:
+		if val.IsNil() {
+			return
+		}
+		res = reflect.New(typ).Elem()
+		res.Set(c.clone(val.Elem(), path, depth))
+	case reflect.Map:
+		if val.IsNil() {
+			return
+		}
+		visit := valueVisit{val.Pointer(), typ}
+		if visited, ok := c.visited[visit]; ok {
+			res = visited
+			return
+		}
+		res = reflect.MakeMapWithSize(typ, val.Len())
+		c.visited[visit] = res
+		iter := val.MapRange()
+		for iter.Next() {
+			res.SetMapIndex(iter.Key(), c.clone(iter.Value(), path, depth+1))
+		}
+	case reflect.Slice:
+		if val.IsNil() {
+			return
+		}
+		res = reflect.MakeSlice(typ, val.Len(), val.Cap())
+		c.cloneList(res, val, path, depth)
+	case reflect.Array:
+		res = reflect.New(typ).Elem()
+		c.cloneList(res, val, path, depth)
+	case reflect.Struct:
+		res = reflect.New(typ).Elem()
+		val = exposeValueOpt(val, c.opt)
+		if val.CanInterface() {
+			// Copy the unexported fields shallowly before copying the exported fields.
+			res.Set(val)
+		}
+		c.cloneStruct(res, val, path, depth)
+	}
+	return
+}
+
+func (c *cloner) cloneList(res reflect.Value, val reflect.Value, path string, depth int) {
+	for index := 0; index < val.Len(); index++ {
+		res.Index(index).Set(c.clone(val.Index(index), path, depth+1))
+	}
+}
+
+func (c *cloner) cloneStruct(res reflect.Value, val reflect.Value, path string, depth int) {
+	typ := val.Type()
+	for index := 0; index < typ.NumField(); index++ {
+		field := typ.Field(index)
+		resField := res.Field(index)
+		srcField := val.Field(index)
+		if field.PkgPath != "" {
+			if !c.opt.UnexportedAccess {
+				continue
+			}
+			// The shallow copy inside res has the same content as the source field and it is always addressable.
+			resField = exposeValue(resField)
+			srcField = resField
+		}
+
+		fieldPath := joinFieldPath(path, field.Name)
+		if c.isSkipped(field, fieldPath) {
+			SetReflectZero(resField)
+			continue
+		}
+		resField.Set(c.clone(srcField, fieldPath, depth+1))
+	}
+}
+
+func joinFieldPath(parent string, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
@@ -0,0 +1,323 @@
+package reflecthelper
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// FuncComparator is a custom function to compare the left and the right of reflect.Value.
+// It returns a negative number if left < right, zero if left == right, and a positive number if left > right.
+type FuncComparator func(left reflect.Value, right reflect.Value) int
+
+func isKindNumber(kind reflect.Kind) bool {
+	return IsKindInt(kind) || IsKindUint(kind) || IsKindFloat(kind)
+}
+
+func isValueDecimal(val reflect.Value) bool {
+	return GetType(val) == TypeDecimal
+}
+
+func isCompareNil(val reflect.Value) bool {
+	return !val.IsValid() || IsValueElemable(val)
+}
+
+func compareInt64(left int64, right int64) int {
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	}
+	return 0
+}
+
+func compareUint64(left uint64, right uint64) int {
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	}
+	return 0
+}
+
+func compareBool(left bool, right bool) int {
+	switch {
+	case left == right:
+		return 0
+	case !left:
+		return -1
+	}
+	return 1
+}
+
+func compareTime(left time.Time, right time.Time) int {
+	switch {
+	case left.Before(right):
+		return -1
+	case left.After(right):
+		return 1
+	}
+	return 0
+}
+
+func toBigFloat(val reflect.Value) (res *big.Float, err error) {
+	switch kind := GetKind(val); {
+	case IsKindInt(kind):
+		res = new(big.Float).SetInt64(val.Int())
+	case IsKindUint(kind):
+		res = new(big.Float).SetUint64(val.Uint())
+	default:
+		floatVal := val.Float()
+		if math.IsNaN(floatVal) {
+			err = getErrNaN(val)
+			return
+		}
+		res = new(big.Float).SetFloat64(floatVal)
+	}
+	return
+}
+
+// compareNumberValue compares the numbers of int, uint, and float kinds without overflow or precision loss.
+func compareNumberValue(left reflect.Value, right reflect.Value) (res int, err error) {
+	leftKind, rightKind := GetKind(left), GetKind(right)
+	switch {
+	case IsKindInt(leftKind) && IsKindInt(rightKind):
+		res = compareInt64(left.Int(), right.Int())
+		return
+	case IsKindUint(leftKind) && IsKindUint(rightKind):
+		res = compareUint64(left.Uint(), right.Uint())
+		return
+	case IsKindInt(leftKind) && IsKindUint(rightKind):
+		if left.Int() < 0 {
+			res = -1
+			return
+		}
+		res = compareUint64(uint64(left.Int()), right.Uint())
+		return
+	case IsKindUint(leftKind) && IsKindInt(rightKind):
+		res, err = compareNumberValue(right, left)
+		res = -res
+		return
+	}
+
+	leftFloat, err := toBigFloat(left)
+	if err != nil {
+		return
+	}
+	rightFloat, err := toBigFloat(right)
+	if err != nil {
+		return
+	}
+	res = leftFloat.Cmp(rightFloat)
+	return
+}
+
+func toDecimal(val reflect.Value, opt *Option) (res decimal.Decimal, err error) {
+	switch kind := GetKind(val); {
+	case isValueDecimal(val):
+		res = val.Interface().(decimal.Decimal)
+	case IsKindInt(kind):
+		res = decimal.NewFromInt(val.Int())
+	case IsKindUint(kind):
+		res = decimal.NewFromBigInt(new(big.Int).SetUint64(val.Uint()), 0)
+	case IsKindFloat(kind):
+		floatVal := val.Float()
+		if math.IsNaN(floatVal) || math.IsInf(floatVal, 0) {
+			err = getErrNaN(val)
+			return
+		}
+		res = decimal.NewFromFloat(floatVal)
+	default:
+		var str string
+		str, err = extractString(val, opt.resetCheck())
+		if err != nil {
+			return
+		}
+		res, err = decimal.NewFromString(strings.TrimSpace(str))
+	}
+	return
+}
+
+func compareDecimal(left reflect.Value, right reflect.Value, opt *Option) (res int, err error) {
+	leftDec, err := toDecimal(left, opt)
+	if err != nil {
+		return
+	}
+	rightDec, err := toDecimal(right, opt)
+	if err != nil {
+		return
+	}
+	res = leftDec.Cmp(rightDec)
+	return
+}
+
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
+
+func getChunkEnd(str string, start int) (end int) {
+	digit := isDigit(str[start])
+	for end = start; end < len(str) && isDigit(str[end]) == digit; end++ {
+	}
+	return
+}
+
+// compareNaturalString compares the strings by treating the digit sequences as numbers, e.g. "file2" < "file10".
+func compareNaturalString(left string, right string) int {
+	for len(left) > 0 && len(right) > 0 {
+		leftEnd, rightEnd := getChunkEnd(left, 0), getChunkEnd(right, 0)
+		leftChunk, rightChunk := left[:leftEnd], right[:rightEnd]
+		if isDigit(leftChunk[0]) && isDigit(rightChunk[0]) {
+			leftNum, rightNum := strings.TrimLeft(leftChunk, "0"), strings.TrimLeft(rightChunk, "0")
+			if res := compareInt64(int64(len(leftNum)), int64(len(rightNum))); res != 0 {
+				return res
+			}
+			if res := strings.Compare(leftNum, rightNum); res != 0 {
+				return res
+			}
+		}
+		if res := strings.Compare(leftChunk, rightChunk); res != 0 {
+			return res
+		}
+		left, right = left[leftEnd:], right[rightEnd:]
+	}
+	return compareInt64(int64(len(left)), int64(len(right)))
+}
+
+func compareString(left string, right string, opt *Option) int {
+	if opt.NaturalStringOrder {
+		return compareNaturalString(left, right)
+	}
+	return strings.Compare(left, right)
+}
+
+func compareAsString(left reflect.Value, right reflect.Value, opt *Option) (res int, err error) {
+	leftStr, err := extractString(left, opt.resetCheck())
+	if err != nil {
+		err = getErrIncomparable(left, right)
+		return
+	}
+	rightStr, err := extractString(right, opt.resetCheck())
+	if err != nil {
+		err = getErrIncomparable(left, right)
+		return
+	}
+	res = compareString(leftStr, rightStr, opt)
+	return
+}
+
+func compareAsTime(left reflect.Value, right reflect.Value, opt *Option) (res int, err error) {
+	leftTime, err := extractTime(left, opt.resetCheck())
+	if err != nil {
+		return
+	}
+	rightTime, err := extractTime(right, opt.resetCheck())
+	if err != nil {
+		return
+	}
+	res = compareTime(leftTime, rightTime)
+	return
+}
+
+// compareAsNumber compares the number with the other value coerced using extractFloat.
+// It falls back to the string comparison if the other value can't be coerced.
+func compareAsNumber(number reflect.Value, other reflect.Value, opt *Option) (res int, err error) {
+	otherFloat, err := extractFloat(other, opt.resetCheck())
+	if err != nil {
+		res, err = compareAsString(number, other, opt)
+		return
+	}
+	res, err = compareNumberValue(number, reflect.ValueOf(otherFloat))
+	return
+}
+
+// compareValues compares the left and the right with the ordering semantics based on their kinds.
+// Nil is less than any non-nil value, numbers of any kind are compared numerically,
+// strings lexically or naturally, and times chronologically.
+func compareValues(left reflect.Value, right reflect.Value, opt *Option) (res int, err error) {
+	left, right = GetChildElem(left), GetChildElem(right)
+	leftNil, rightNil := isCompareNil(left), isCompareNil(right)
+	switch {
+	case leftNil && rightNil:
+		return
+	case leftNil:
+		res = -1
+		return
+	case rightNil:
+		res = 1
+		return
+	}
+
+	leftKind, rightKind := GetKind(left), GetKind(right)
+	switch {
+	case isKindNumber(leftKind) && isKindNumber(rightKind):
+		res, err = compareNumberValue(left, right)
+	case isValueDecimal(left) || isValueDecimal(right):
+		res, err = compareDecimal(left, right, opt)
+	case IsKindString(leftKind) && IsKindString(rightKind):
+		res = compareString(left.String(), right.String(), opt)
+	case IsKindBool(leftKind) && IsKindBool(rightKind):
+		res = compareBool(left.Bool(), right.Bool())
+	case IsTypeValueTime(left) || IsTypeValueTime(right):
+		res, err = compareAsTime(left, right, opt)
+	case isKindNumber(leftKind):
+		res, err = compareAsNumber(left, right, opt)
+	case isKindNumber(rightKind):
+		res, err = compareAsNumber(right, left, opt)
+		res = -res
+	default:
+		res, err = compareAsString(left, right, opt)
+	}
+	return
+}
+
+// compareNatural is like compareValues but it orders the incomparable values by their kinds.
+func compareNatural(left reflect.Value, right reflect.Value, opt *Option) int {
+	res, err := compareValues(left, right, opt)
+	if err != nil {
+		return compareInt64(int64(GetKind(GetChildElem(left))), int64(GetKind(GetChildElem(right))))
+	}
+	return res
+}
+
+func getMapKeyComparator(opt *Option) (comparator FuncComparator) {
+	comparator = opt.MapKeyComparator
+	if comparator == nil {
+		comparator = func(left reflect.Value, right reflect.Value) int {
+			return compareNatural(left, right, opt)
+		}
+	}
+	return
+}
+
+// mapEntry is the key and the value of a map entry.
+type mapEntry struct {
+	key   reflect.Value
+	value reflect.Value
+}
+
+// getMapEntries returns the entries of the map, the entries are sorted by the keys if the SortMapKeys option is true.
+// The values are taken from MapRange because MapIndex can't look up the NaN keys.
+func getMapEntries(val reflect.Value, opt *Option) (entries []mapEntry) {
+	entries = make([]mapEntry, 0, val.Len())
+	iter := val.MapRange()
+	for iter.Next() {
+		entries = append(entries, mapEntry{key: iter.Key(), value: iter.Value()})
+	}
+	if !opt.SortMapKeys {
+		return
+	}
+
+	comparator := getMapKeyComparator(opt)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return comparator(entries[i].key, entries[j].key) < 0
+	})
+	return
+}
@@ -0,0 +1,15 @@
+package reflecthelper
+
+// Compare compares a and b with the ordering semantics based on their kinds.
+// It returns -1 if a < b, 0 if a == b, and 1 if a > b.
+// Nil is less than any non-nil value.
+// Numbers of different kinds, including decimal.Decimal, are compared numerically without overflow.
+// Strings are compared lexically, or naturally if WithNaturalStringOrder is used.
+// Times are compared chronologically and a string is parsed as time if the other side is a time.
+// A number and a numeric string are compared numerically if the string can be extracted by ExtractFloat.
+// Compare returns an error if a and b are incomparable.
+func Compare(a interface{}, b interface{}, fnOpts ...FuncOption) (res int, err error) {
+	opt := NewOption().Assign(fnOpts...)
+	res, err = compareValues(getValFromInterface(a), getValFromInterface(b), opt)
+	return
+}
@@ -0,0 +1,119 @@
+package reflecthelper
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_compareNatural(t *testing.T) {
+	tests := []struct {
+		name  string
+		left  interface{}
+		right interface{}
+		want  int
+	}{
+		{name: "int less", left: 1, right: 2, want: -1},
+		{name: "int equal", left: int8(2), right: int64(2), want: 0},
+		{name: "uint greater", left: uint(3), right: uint8(2), want: 1},
+		{name: "negative int and uint", left: -1, right: uint64(math.MaxUint64), want: -1},
+		{name: "uint and int", left: uint64(math.MaxUint64), right: math.MaxInt64, want: 1},
+		{name: "float and int", left: 1.5, right: 2, want: -1},
+		{name: "string", left: "b", right: "a", want: 1},
+		{name: "bool", left: false, right: true, want: -1},
+		{name: "bool equal", left: true, right: true, want: 0},
+		{name: "numeric string and number", left: "10", right: 9, want: 1},
+		{name: "string and number fallback", left: "abc", right: 9, want: 1},
+		{name: "unextractable kinds", left: struct{}{}, right: []int{}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := compareNatural(reflect.ValueOf(tt.left), reflect.ValueOf(tt.right), NewDefaultOption())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_getMapEntries(t *testing.T) {
+	t.Run("interface keys", func(t *testing.T) {
+		input := map[interface{}]int{"b": 1, 10: 2, 2: 3, 1.5: 4, "a": 5}
+		entries := getMapEntries(reflect.ValueOf(input), NewDefaultOption().Assign(WithSortedMapKeys(true)))
+
+		var (
+			keys   []interface{}
+			values []int
+		)
+		for _, entry := range entries {
+			keys = append(keys, entry.key.Interface())
+			values = append(values, int(entry.value.Int()))
+		}
+		assert.Equal(t, []interface{}{1.5, 2, 10, "a", "b"}, keys)
+		assert.Equal(t, []int{4, 3, 2, 5, 1}, values)
+	})
+	t.Run("custom comparator", func(t *testing.T) {
+		input := map[string]int{"a": 1, "c": 2, "b": 3}
+		entries := getMapEntries(reflect.ValueOf(input), NewDefaultOption().Assign(WithMapKeyComparator(func(left, right reflect.Value) int {
+			return -compareNatural(left, right, NewDefaultOption())
+		})))
+		assert.Equal(t, "c", entries[0].key.String())
+		assert.Equal(t, "a", entries[2].key.String())
+	})
+}
+
+func TestCompare(t *testing.T) {
+	base := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		a       interface{}
+		b       interface{}
+		opts    []FuncOption
+		want    int
+		wantErr bool
+	}{
+		{name: "nil and nil", a: nil, b: (*int)(nil), want: 0},
+		{name: "nil and value", a: nil, b: 1, want: -1},
+		{name: "value and nil", a: 1, b: nil, want: 1},
+		{name: "int64 and uint64 max", a: int64(math.MaxInt64), b: uint64(math.MaxUint64), want: -1},
+		{name: "uint64 and negative int", a: uint64(0), b: int8(-1), want: 1},
+		{name: "int and float exact", a: int64(1<<53 + 1), b: float64(1 << 53), want: 1},
+		{name: "uint and float", a: uint64(math.MaxUint64), b: math.MaxFloat64, want: -1},
+		{name: "float equal int", a: 2.0, b: 2, want: 0},
+		{name: "float NaN", a: math.NaN(), b: 1, wantErr: true},
+		{name: "decimal and int", a: decimal.NewFromFloat(1.5), b: 2, want: -1},
+		{name: "decimal and uint", a: decimal.RequireFromString("18446744073709551616"), b: uint64(math.MaxUint64), want: 1},
+		{name: "decimal and float", a: decimal.RequireFromString("0.1"), b: 0.1, want: 0},
+		{name: "decimal and string", a: &decimal.Decimal{}, b: "-0.5", want: 1},
+		{name: "decimal and infinite float", a: decimal.Zero, b: math.Inf(1), wantErr: true},
+		{name: "decimal and invalid string", a: decimal.Zero, b: "abc", wantErr: true},
+		{name: "string lexical", a: "file10", b: "file2", want: -1},
+		{name: "string natural", a: "file10", b: "file2", opts: []FuncOption{WithNaturalStringOrder(true)}, want: 1},
+		{name: "string natural leading zero", a: "v002", b: "v2", opts: []FuncOption{WithNaturalStringOrder(true)}, want: -1},
+		{name: "string natural prefix", a: "a", b: "a1", opts: []FuncOption{WithNaturalStringOrder(true)}, want: -1},
+		{name: "string natural equal", a: "x10y", b: "x10y", opts: []FuncOption{WithNaturalStringOrder(true)}, want: 0},
+		{name: "bool", a: true, b: false, want: 1},
+		{name: "time", a: base, b: base.Add(time.Second), want: -1},
+		{name: "time pointer and string", a: &base, b: "2020-12-31T00:00:00Z", want: 1},
+		{name: "time and invalid string", a: base, b: "abc", wantErr: true},
+		{name: "number and numeric string", a: 10, b: "9.5", want: 1},
+		{name: "numeric string and number", a: "9.5", b: 10, want: -1},
+		{name: "number and bool", a: 1, b: true, want: 0},
+		{name: "number and text fallback", a: 10, b: "abc", want: -1},
+		{name: "incomparable", a: struct{}{}, b: []int{}, wantErr: true},
+		{name: "incomparable right", a: "abc", b: struct{}{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compare(tt.a, tt.b, tt.opts...)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
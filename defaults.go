@@ -0,0 +1,184 @@
+package reflecthelper
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TagDefault is the struct tag key read by SetDefaults.
+const TagDefault = "default"
+
+// Defaulter is the interface called by SetDefaults after the default values of the struct fields are set.
+type Defaulter interface {
+	SetDefaults()
+}
+
+var (
+	typeDefaulter    = reflect.TypeOf((*Defaulter)(nil)).Elem()
+	defaultTypeCache sync.Map
+)
+
+// hasDefaultTag checks whether the struct type or any of its descendants has a default tag or implements Defaulter.
+func hasDefaultTag(typ reflect.Type, visiting map[reflect.Type]struct{}) (res bool) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || typ == TypeTime || typ == TypeURL {
+		return
+	}
+	if cached, ok := defaultTypeCache.Load(typ); ok {
+		res = cached.(bool)
+		return
+	}
+	if _, ok := visiting[typ]; ok {
+		return
+	}
+
+	visiting[typ] = struct{}{}
+	res = reflect.PtrTo(typ).Implements(typeDefaulter)
+	for index := 0; index < typ.NumField() && !res; index++ {
+		field := typ.Field(index)
+		tag := field.Tag.Get(TagDefault)
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+		res = tag != "" || hasDefaultTag(field.Type, visiting)
+	}
+	delete(visiting, typ)
+
+	// The result is only cached at the root because the result inside a cycle may be incomplete.
+	if len(visiting) == 0 {
+		defaultTypeCache.Store(typ, res)
+	}
+	return
+}
+
+type defaulter struct {
+	opt       *Option
+	errs      MultiError
+	ancestors map[valueVisit]struct{}
+}
+
+func newDefaulter(opt *Option) *defaulter {
+	return &defaulter{
+		opt:       opt,
+		ancestors: make(map[valueVisit]struct{}),
+	}
+}
+
+func (d *defaulter) set(val reflect.Value, path string) {
+	visit, tracked := getValueVisit(val)
+	if tracked {
+		if _, ok := d.ancestors[visit]; ok {
+			return
+		}
+		d.ancestors[visit] = struct{}{}
+		defer delete(d.ancestors, visit)
+	}
+
+	switch GetKind(val) {
+	case reflect.Ptr:
+		if val.IsNil() {
+			if !val.CanSet() || !hasDefaultTag(val.Type(), make(map[reflect.Type]struct{})) {
+				return
+			}
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		d.set(val.Elem(), path)
+	case reflect.Struct:
+		if IsTypeValueTime(val) || IsTypeValueURL(val) {
+			return
+		}
+		d.setStruct(val, path)
+	case reflect.Array, reflect.Slice:
+		for index := 0; index < val.Len(); index++ {
+			d.set(val.Index(index), path+"["+strconv.Itoa(index)+"]")
+		}
+	}
+}
+
+func (d *defaulter) setStruct(val reflect.Value, path string) {
+	typ := val.Type()
+	for index := 0; index < val.NumField(); index++ {
+		field := typ.Field(index)
+		tag := field.Tag.Get(TagDefault)
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+
+		fieldVal := val.Field(index)
+		fieldPath := joinFieldPath(path, field.Name)
+		if tag != "" && IsValueZero(fieldVal) {
+			if err := assignDefaultTag(fieldVal, tag, d.opt); err != nil {
+				d.errs = append(d.errs, getErrDefaultTag(fieldPath, setErrorPath(err, fieldPath)))
+				continue
+			}
+		}
+		d.set(fieldVal, fieldPath)
+	}
+
+	if val.CanAddr() {
+		val = val.Addr()
+	}
+	if val.CanInterface() {
+		if res, ok := val.Interface().(Defaulter); ok {
+			res.SetDefaults()
+		}
+	}
+}
+
+// assignDefaultTag assigns the value of the default tag to the val.
+// The tag is split by comma for arrays and slices, e.g. a,b,
+// and split by comma and colon for maps, e.g. a:1,b:2.
+func assignDefaultTag(val reflect.Value, tag string, opt *Option) (err error) {
+	typ := val.Type()
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch {
+	case typ == TypeIP:
+	case typ.Kind() == reflect.Array || typ.Kind() == reflect.Slice:
+		if typ.Elem().Kind() != reflect.Uint8 && typ.Elem().Kind() != reflect.Int32 {
+			err = assignReflect(val, reflect.ValueOf(strings.Split(tag, ",")), opt)
+			return
+		}
+	case typ.Kind() == reflect.Map:
+		var res reflect.Value
+		res, err = parseDefaultMap(typ, tag, opt)
+		if err != nil {
+			return
+		}
+		err = assignReflect(val, res, opt)
+		return
+	}
+
+	err = assignReflect(val, reflect.ValueOf(tag), opt)
+	return
+}
+
+func parseDefaultMap(typ reflect.Type, tag string, opt *Option) (res reflect.Value, err error) {
+	res = reflect.MakeMap(typ)
+	for _, pair := range strings.Split(tag, ",") {
+		index := strings.IndexByte(pair, ':')
+		if index < 0 {
+			err = getErrInvalidDefaultMap(pair)
+			return
+		}
+
+		key := reflect.New(typ.Key()).Elem()
+		err = assignReflect(key, reflect.ValueOf(pair[:index]), opt)
+		if err != nil {
+			return
+		}
+		elem := reflect.New(typ.Elem()).Elem()
+		err = assignReflect(elem, reflect.ValueOf(pair[index+1:]), opt)
+		if err != nil {
+			return
+		}
+		res.SetMapIndex(key, elem)
+	}
+	return
+}
@@ -0,0 +1,37 @@
+package reflecthelper
+
+// SetDefaults sets the zero-valued fields of the input using the default struct tag, e.g. `default:"30s"`.
+// The input must be a pointer or a settable reflect.Value.
+// The value of the tag is assigned using AssignReflect.
+// The tag is split by comma for arrays and slices, e.g. `default:"a,b"`,
+// and split by comma and colon for maps, e.g. `default:"a:1,b:2"`.
+//
+// SetDefaults recurses into the nested structs, pointers, arrays, and slices.
+// The nil pointers are only initialized if any of their descendants has a default tag or implements Defaulter.
+// Defaulter is called after the default values of the struct fields are set.
+// Unexported fields and fields tagged with `default:"-"` are skipped.
+//
+// SetDefaults returns MultiError containing the errors of all fields failed to be assigned.
+func SetDefaults(input interface{}, fnOpts ...FuncOption) (err error) {
+	opt := NewOption().Assign(fnOpts...)
+	defer recoverFnOpt(&err, opt)
+
+	val := getValFromInterface(input)
+	if IsValueElemable(val) && val.IsNil() {
+		err = ErrAssignerCantSet
+		return
+	}
+
+	child := GetChildElem(val)
+	if !child.CanSet() {
+		err = ErrAssignerCantSet
+		return
+	}
+
+	d := newDefaulter(opt)
+	d.set(child, "")
+	if len(d.errs) > 0 {
+		err = d.errs
+	}
+	return
+}
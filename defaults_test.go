@@ -0,0 +1,107 @@
+package reflecthelper
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type defaultsDatabase struct {
+	Host    string `default:"localhost"`
+	Port    int    `default:"5432"`
+	Ignored string
+}
+
+type defaultsCache struct {
+	Size int
+}
+
+type defaultsHooked struct {
+	Name   string `default:"hooked"`
+	Called bool
+}
+
+func (d *defaultsHooked) SetDefaults() {
+	d.Called = d.Name == "hooked"
+}
+
+type defaultsConfig struct {
+	Timeout  time.Duration  `default:"30s"`
+	Tags     []string       `default:"a,b"`
+	Ports    []int          `default:"80,443"`
+	Limits   map[string]int `default:"x:1,y:2"`
+	Endpoint *url.URL       `default:"https://x.example.com/path"`
+	Enabled  bool           `default:"true"`
+	Ratio    *float64       `default:"0.5"`
+	Data     []byte         `default:"raw"`
+	Start    time.Time      `default:"2021-01-02T03:04:05Z"`
+	Skip     string         `default:"-"`
+	Set      string         `default:"unused"`
+	Database *defaultsDatabase
+	Cache    *defaultsCache
+	Hooked   *defaultsHooked
+	Replicas []defaultsDatabase
+	Self     *defaultsConfig
+	private  string `default:"private"`
+}
+
+func TestSetDefaults(t *testing.T) {
+	cfg := defaultsConfig{
+		Set:      "already",
+		Replicas: []defaultsDatabase{{Host: "replica"}, {}},
+	}
+	cfg.Self = &cfg
+	err := SetDefaults(&cfg)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 30*time.Second, cfg.Timeout)
+	assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+	assert.Equal(t, []int{80, 443}, cfg.Ports)
+	assert.Equal(t, map[string]int{"x": 1, "y": 2}, cfg.Limits)
+	assert.Equal(t, "https://x.example.com/path", cfg.Endpoint.String())
+	assert.True(t, cfg.Enabled)
+	assert.Equal(t, 0.5, *cfg.Ratio)
+	assert.Equal(t, []byte("raw"), cfg.Data)
+	assert.Equal(t, time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC), cfg.Start.UTC())
+	assert.Empty(t, cfg.Skip)
+	assert.Equal(t, "already", cfg.Set)
+	assert.Equal(t, &defaultsDatabase{Host: "localhost", Port: 5432}, cfg.Database)
+	assert.Nil(t, cfg.Cache)
+	assert.Equal(t, &defaultsHooked{Name: "hooked", Called: true}, cfg.Hooked)
+	assert.Equal(t, []defaultsDatabase{{Host: "replica", Port: 5432}, {Host: "localhost", Port: 5432}}, cfg.Replicas)
+	assert.Empty(t, cfg.private)
+
+	t.Run("reflect value", func(t *testing.T) {
+		var db defaultsDatabase
+		assert.Nil(t, SetDefaults(reflect.ValueOf(&db).Elem()))
+		assert.Equal(t, defaultsDatabase{Host: "localhost", Port: 5432}, db)
+	})
+
+	t.Run("unsettable input", func(t *testing.T) {
+		var nilCfg *defaultsConfig
+		assert.Equal(t, ErrAssignerCantSet, SetDefaults(nilCfg))
+		assert.Equal(t, ErrAssignerCantSet, SetDefaults(defaultsDatabase{}))
+	})
+
+	t.Run("invalid defaults", func(t *testing.T) {
+		type invalid struct {
+			Port   int            `default:"abc"`
+			Limits map[string]int `default:"x"`
+			Name   string         `default:"ok"`
+		}
+		var res invalid
+		err := SetDefaults(&res)
+		assert.NotNil(t, err)
+
+		var multiErr MultiError
+		assert.True(t, errors.As(err, &multiErr))
+		assert.Len(t, multiErr, 2)
+		assert.Contains(t, multiErr[0].Error(), "Port")
+		assert.Contains(t, multiErr[1].Error(), "Limits")
+		assert.Equal(t, "ok", res.Name)
+	})
+}
@@ -0,0 +1,244 @@
+package reflecthelper
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// List of the days used by the ISO 8601 durations, the year and the month are approximated.
+const (
+	durationDay   = 24 * time.Hour
+	durationWeek  = 7 * durationDay
+	durationMonth = 30 * durationDay
+	durationYear  = 365 * durationDay
+)
+
+var (
+	durationUnits = map[string]time.Duration{
+		"ns": time.Nanosecond, "nanosecond": time.Nanosecond, "nanoseconds": time.Nanosecond,
+		"us": time.Microsecond, "µs": time.Microsecond, "μs": time.Microsecond,
+		"microsecond": time.Microsecond, "microseconds": time.Microsecond,
+		"ms": time.Millisecond, "millisecond": time.Millisecond, "milliseconds": time.Millisecond,
+		"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+		"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+		"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+		"d": durationDay, "day": durationDay, "days": durationDay,
+		"w": durationWeek, "wk": durationWeek, "wks": durationWeek, "week": durationWeek, "weeks": durationWeek,
+	}
+
+	// durationTermRegex matches the number followed by the unit, e.g. 1.5 hours or 2w.
+	durationTermRegex = regexp.MustCompile(`^(\d+(?:\.\d*)?|\.\d+)\s*([^\d\s.,]*)`)
+	// durationClockRegex matches the clock format, e.g. 01:30, 01:30:00, or 01:30:00.5.
+	durationClockRegex = regexp.MustCompile(`^(\d+):(\d{1,2})(?::(\d{1,2}(?:\.\d+)?))?$`)
+	// durationISORegex matches the ISO 8601 duration, e.g. P1Y2M3W4DT5H6M7.5S.
+	durationISORegex = regexp.MustCompile(
+		`^[Pp](?:(\d+(?:\.\d+)?)[Yy])?(?:(\d+(?:\.\d+)?)[Mm])?(?:(\d+(?:\.\d+)?)[Ww])?(?:(\d+(?:\.\d+)?)[Dd])?` +
+			`(?:[Tt](?:(\d+(?:\.\d+)?)[Hh])?(?:(\d+(?:\.\d+)?)[Mm])?(?:(\d+(?:\.\d+)?)[Ss])?)?$`,
+	)
+	durationISOUnits = []time.Duration{durationYear, durationMonth, durationWeek, durationDay, time.Hour, time.Minute, time.Second}
+)
+
+// durationBuilder sums the terms of the duration without overflowing the time.Duration.
+// If calendarDays is true, the whole number of the units in days, e.g. 2 days or 1w,
+// is summed separately as the calendar days.
+type durationBuilder struct {
+	input        string
+	negative     bool
+	calendarDays bool
+	total        decimal.Decimal
+	days         decimal.Decimal
+}
+
+func (d *durationBuilder) add(number string, unit time.Duration) (err error) {
+	value, err := decimal.NewFromString(number)
+	if err != nil {
+		err = getErrInvalidDuration(d.input)
+		return
+	}
+
+	if d.calendarDays && value.Equal(value.Truncate(0)) && unit%durationDay == 0 {
+		d.days = d.days.Add(value.Mul(decimal.NewFromInt(int64(unit / durationDay))))
+		return
+	}
+	d.total = d.total.Add(value.Mul(decimal.NewFromInt(int64(unit))))
+	return
+}
+
+func (d *durationBuilder) result() (res time.Duration, err error) {
+	total := d.total.Round(0)
+	if d.negative {
+		total = total.Neg()
+	}
+	if total.GreaterThan(decimal.NewFromInt(math.MaxInt64)) || total.LessThan(decimal.NewFromInt(math.MinInt64)) {
+		err = getErrOverflowDuration(d.input)
+		return
+	}
+
+	res = time.Duration(total.IntPart())
+	return
+}
+
+// resultDays returns the calendar days summed when the calendarDays is true.
+func (d *durationBuilder) resultDays() (res int, err error) {
+	days := d.days
+	if d.negative {
+		days = days.Neg()
+	}
+	if days.GreaterThan(decimal.NewFromInt(math.MaxInt32)) || days.LessThan(decimal.NewFromInt(math.MinInt32)) {
+		err = getErrOverflowDuration(d.input)
+		return
+	}
+
+	res = int(days.IntPart())
+	return
+}
+
+// convertDuration converts the number of val in the unit set by WithDurationUnit to the time.Duration.
+func convertDuration(val reflect.Value, option *Option) (res time.Duration, err error) {
+	value, err := toDecimal(val, option)
+	if err != nil {
+		err = getErrParse(val, TypeDuration, err)
+		return
+	}
+
+	total := value.Mul(decimal.NewFromInt(int64(option.DurationUnit))).Round(0)
+	if total.GreaterThan(decimal.NewFromInt(math.MaxInt64)) || total.LessThan(decimal.NewFromInt(math.MinInt64)) {
+		err = getErrOverflowExtract(val, TypeDuration)
+		return
+	}
+
+	res = time.Duration(total.IntPart())
+	return
+}
+
+// parseDuration parses the durTxt using time.ParseDuration,
+// and then the extended formats if the extended duration is enabled.
+func parseDuration(durTxt string, option *Option) (res time.Duration, err error) {
+	res, err = time.ParseDuration(durTxt)
+	if err == nil || !option.ExtendedDuration {
+		return
+	}
+
+	res, err = parseExtendedDuration(durTxt, option)
+	return
+}
+
+// parseExtendedDuration parses the durTxt in the extended formats,
+// the unit names and the unit of the number are taken from the option.
+func parseExtendedDuration(durTxt string, option *Option) (res time.Duration, err error) {
+	builder := &durationBuilder{input: durTxt}
+	err = builder.parse(option)
+	if err != nil {
+		return
+	}
+
+	res, err = builder.result()
+	return
+}
+
+// parse adds the terms of the input in the extended formats to the builder.
+func (d *durationBuilder) parse(option *Option) (err error) {
+	input := strings.TrimSpace(d.input)
+	d.negative = strings.HasPrefix(input, "-")
+	input = strings.TrimLeft(input, "+-")
+	switch {
+	case input == "":
+		err = getErrInvalidDuration(d.input)
+	case durationISORegex.MatchString(input) && len(input) > 1 && !strings.HasSuffix(strings.ToUpper(input), "T"):
+		err = d.addISO(input)
+	case durationClockRegex.MatchString(input):
+		err = d.addClock(input)
+	default:
+		err = d.addTerms(input, option)
+	}
+	return
+}
+
+func (d *durationBuilder) addISO(input string) (err error) {
+	matches := durationISORegex.FindStringSubmatch(input)
+	for index, unit := range durationISOUnits {
+		if matches[index+1] == "" {
+			continue
+		}
+		err = d.add(matches[index+1], unit)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (d *durationBuilder) addClock(input string) (err error) {
+	matches := durationClockRegex.FindStringSubmatch(input)
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	for index, unit := range units {
+		if matches[index+1] == "" {
+			continue
+		}
+		err = d.add(matches[index+1], unit)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// addTerms adds the terms like 1d 2h30m, 1.5 hours, or 1 day and 2 hours.
+// The number without the unit is in the unit set by WithDurationUnit.
+func (d *durationBuilder) addTerms(input string, option *Option) (err error) {
+	for input != "" {
+		matches := durationTermRegex.FindStringSubmatch(input)
+		if matches == nil {
+			err = getErrInvalidDuration(d.input)
+			return
+		}
+
+		unit, ok := getDurationUnit(matches[2], option)
+		if !ok {
+			err = getErrInvalidDuration(d.input)
+			return
+		}
+		err = d.add(matches[1], unit)
+		if err != nil {
+			return
+		}
+		input = trimDurationSeparator(input[len(matches[0]):])
+	}
+	return
+}
+
+func getDurationUnit(name string, option *Option) (unit time.Duration, ok bool) {
+	if name == "" {
+		unit, ok = getNumericDurationUnit(option), true
+		return
+	}
+
+	name = strings.ToLower(name)
+	unit, ok = option.DurationUnits[name]
+	if ok {
+		return
+	}
+	unit, ok = durationUnits[name]
+	return
+}
+
+func getNumericDurationUnit(option *Option) (unit time.Duration) {
+	unit = option.DurationUnit
+	if unit <= 0 {
+		unit = time.Nanosecond
+	}
+	return
+}
+
+func trimDurationSeparator(input string) string {
+	input = strings.TrimLeft(input, " \t,")
+	if strings.HasPrefix(strings.ToLower(input), "and ") {
+		input = strings.TrimLeft(input[len("and "):], " \t")
+	}
+	return input
+}
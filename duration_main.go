@@ -0,0 +1,11 @@
+package reflecthelper
+
+import "time"
+
+// ParseDuration parses the durTxt string to the time.Duration.
+// The extended formats like 1d, 2w, 1.5 hours, 01:30:00, and P1DT2H30M are parsed if WithExtendedDuration is used.
+func ParseDuration(durTxt string, fnOpts ...FuncOption) (result time.Duration, err error) {
+	opt := NewOption().Assign(fnOpts...)
+	result, err = parseDuration(durTxt, opt)
+	return
+}
@@ -0,0 +1,184 @@
+package reflecthelper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration(t *testing.T) {
+	extended := WithExtendedDuration(true)
+	tests := []struct {
+		name    string
+		durTxt  string
+		fnOpts  []FuncOption
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			name:   "standard duration",
+			durTxt: "1h30m",
+			want:   90 * time.Minute,
+		},
+		{
+			name:    "days without extended duration",
+			durTxt:  "1d",
+			wantErr: true,
+		},
+		{
+			name:   "days",
+			durTxt: "1d",
+			fnOpts: []FuncOption{extended},
+			want:   24 * time.Hour,
+		},
+		{
+			name:   "weeks and hours",
+			durTxt: "2w 3h",
+			fnOpts: []FuncOption{extended},
+			want:   14*24*time.Hour + 3*time.Hour,
+		},
+		{
+			name:   "compact mixed units",
+			durTxt: "1d2h30m",
+			fnOpts: []FuncOption{extended},
+			want:   26*time.Hour + 30*time.Minute,
+		},
+		{
+			name:   "fractional words",
+			durTxt: "1.5 hours",
+			fnOpts: []FuncOption{extended},
+			want:   90 * time.Minute,
+		},
+		{
+			name:   "words with and",
+			durTxt: "1 day, 2 Hours and 5 minutes",
+			fnOpts: []FuncOption{extended},
+			want:   26*time.Hour + 5*time.Minute,
+		},
+		{
+			name:   "negative",
+			durTxt: "-1.5d",
+			fnOpts: []FuncOption{extended},
+			want:   -36 * time.Hour,
+		},
+		{
+			name:   "clock",
+			durTxt: "01:30:00",
+			fnOpts: []FuncOption{extended},
+			want:   90 * time.Minute,
+		},
+		{
+			name:   "clock with fraction",
+			durTxt: "0:01:02.5",
+			fnOpts: []FuncOption{extended},
+			want:   62*time.Second + 500*time.Millisecond,
+		},
+		{
+			name:   "iso 8601",
+			durTxt: "P1DT2H30M",
+			fnOpts: []FuncOption{extended},
+			want:   26*time.Hour + 30*time.Minute,
+		},
+		{
+			name:   "iso 8601 with year, month and week",
+			durTxt: "P1Y1M1W",
+			fnOpts: []FuncOption{extended},
+			want:   (365 + 30 + 7) * 24 * time.Hour,
+		},
+		{
+			name:   "iso 8601 fractional seconds",
+			durTxt: "PT0.5S",
+			fnOpts: []FuncOption{extended},
+			want:   500 * time.Millisecond,
+		},
+		{
+			name:    "incomplete iso 8601",
+			durTxt:  "P1DT",
+			fnOpts:  []FuncOption{extended},
+			wantErr: true,
+		},
+		{
+			name:   "number without unit",
+			durTxt: "90",
+			fnOpts: []FuncOption{extended, WithDurationUnit(time.Second)},
+			want:   90 * time.Second,
+		},
+		{
+			name:   "custom unit",
+			durTxt: "1 fortnight",
+			fnOpts: []FuncOption{extended, WithDurationUnits(map[string]time.Duration{"Fortnight": 14 * 24 * time.Hour})},
+			want:   14 * 24 * time.Hour,
+		},
+		{
+			name:    "unknown unit",
+			durTxt:  "1 parsec",
+			fnOpts:  []FuncOption{extended},
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			durTxt:  " ",
+			fnOpts:  []FuncOption{extended},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDuration(tt.durTxt, tt.fnOpts...)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExtractDurationUnit(t *testing.T) {
+	got, err := ExtractDuration(reflect.ValueOf(90))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(90), got)
+
+	got, err = ExtractDuration(reflect.ValueOf(90), WithDurationUnit(time.Second))
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Second, got)
+
+	got, err = ExtractDuration(reflect.ValueOf(1.5), WithDurationUnit(time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Minute, got)
+
+	_, err = ExtractDuration(reflect.ValueOf(uint64(1<<63)), WithDurationUnit(time.Hour))
+	var overflowErr *OverflowError
+	assert.True(t, errors.As(err, &overflowErr))
+
+	_, err = ExtractDuration(reflect.ValueOf("300000000w"), WithExtendedDuration(true))
+	assert.True(t, errors.As(err, &overflowErr))
+
+	_, err = ExtractDuration(reflect.ValueOf("1 parsec"), WithExtendedDuration(true))
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+
+	var target struct {
+		Timeout time.Duration
+	}
+	err = AssignReflect(reflect.ValueOf(&target.Timeout), reflect.ValueOf("P1D"), WithExtendedDuration(true))
+	assert.Nil(t, err)
+	assert.Equal(t, 24*time.Hour, target.Timeout)
+}
+
+func TestWithDurationUnitsCopy(t *testing.T) {
+	units := map[string]time.Duration{"Fortnight": 14 * 24 * time.Hour}
+	opt := NewOption().Assign(WithDurationUnits(units))
+	cloned := opt.Clone().Assign(WithDurationUnits(map[string]time.Duration{"sprint": 10 * 24 * time.Hour}))
+
+	assert.Equal(t, map[string]time.Duration{"Fortnight": 14 * 24 * time.Hour}, units)
+	assert.Equal(t, map[string]time.Duration{"fortnight": 14 * 24 * time.Hour}, opt.DurationUnits)
+	assert.Equal(t, map[string]time.Duration{
+		"fortnight": 14 * 24 * time.Hour,
+		"sprint":    10 * 24 * time.Hour,
+	}, cloned.DurationUnits)
+}
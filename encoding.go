@@ -0,0 +1,94 @@
+package reflecthelper
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net"
+	"net/url"
+	"reflect"
+	"time"
+)
+
+// BytesEncoding is the encoding used to convert the []byte from or to the string.
+type BytesEncoding uint8
+
+// List of all BytesEncoding.
+const (
+	// BytesRaw converts the []byte as is, e.g. []byte("hi") is "hi".
+	BytesRaw BytesEncoding = iota
+	// BytesHex converts the []byte as the hexadecimal string, e.g. []byte("hi") is "6869".
+	BytesHex
+	// BytesBase64 converts the []byte as the standard base64 string, e.g. []byte("hi") is "aGk=".
+	BytesBase64
+	// BytesBase64URL converts the []byte as the URL-safe base64 string.
+	BytesBase64URL
+)
+
+func encodeBytes(input []byte, option *Option) (result string) {
+	switch option.BytesEncoding {
+	case BytesHex:
+		result = hex.EncodeToString(input)
+	case BytesBase64:
+		result = base64.StdEncoding.EncodeToString(input)
+	case BytesBase64URL:
+		result = base64.URLEncoding.EncodeToString(input)
+	default:
+		result = string(input)
+	}
+	return
+}
+
+func decodeBytes(input string, option *Option) (result []byte, err error) {
+	switch option.BytesEncoding {
+	case BytesHex:
+		result, err = hex.DecodeString(input)
+	case BytesBase64:
+		result, err = base64.StdEncoding.DecodeString(input)
+	case BytesBase64URL:
+		result, err = base64.URLEncoding.DecodeString(input)
+	default:
+		result = []byte(input)
+	}
+	return
+}
+
+func isValueBytes(val reflect.Value) bool {
+	return GetKind(val) == reflect.Slice && GetKindElem(val) == reflect.Uint8
+}
+
+// formatTypedString formats the special types to the string, the ok is false if the type of val is not special.
+// The result can be parsed back to the same type.
+func formatTypedString(val reflect.Value, option *Option) (result string, ok bool) {
+	val = GetChildElem(val)
+	if !val.IsValid() || !val.CanInterface() {
+		return
+	}
+
+	ok = true
+	switch typ := GetType(val); {
+	case typ == TypeDuration:
+		result = val.Interface().(time.Duration).String()
+	case typ == TypeTime:
+		result = normalizeTime(val.Interface().(time.Time), option).Format(getTimeOutputLayout(option))
+	case typ == TypeURL:
+		urlVal := val.Interface().(url.URL)
+		result = urlVal.String()
+	case typ == TypeIP:
+		if ipVal := val.Interface().(net.IP); len(ipVal) > 0 {
+			result = ipVal.String()
+		}
+	case isValueBytes(val):
+		result = encodeBytes(val.Bytes(), option)
+	default:
+		ok = false
+	}
+	return
+}
+
+func getTimeOutputLayout(option *Option) (layout string) {
+	layout = option.TimeOutputLayout
+	if layout == "" {
+		layout = time.RFC3339Nano
+	}
+	return
+}
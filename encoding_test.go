@@ -0,0 +1,165 @@
+package reflecthelper
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractStringTyped(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*3600)
+	timeVal := time.Date(2021, 3, 4, 5, 6, 7, 8, jakarta)
+	urlVal, _ := url.Parse("https://example.com/path?q=1")
+	tests := []struct {
+		name   string
+		input  interface{}
+		fnOpts []FuncOption
+		want   string
+	}{
+		{
+			name:  "duration",
+			input: 90 * time.Minute,
+			want:  "1h30m0s",
+		},
+		{
+			name:  "duration pointer",
+			input: func() *time.Duration { dur := time.Second; return &dur }(),
+			want:  "1s",
+		},
+		{
+			name:  "time",
+			input: timeVal,
+			want:  "2021-03-04T05:06:07.000000008+07:00",
+		},
+		{
+			name:   "time with output layout",
+			input:  &timeVal,
+			fnOpts: []FuncOption{WithTimeOutputLayout("2006-01-02 15:04")},
+			want:   "2021-03-04 05:06",
+		},
+		{
+			name:   "time normalized to UTC",
+			input:  timeVal,
+			fnOpts: []FuncOption{WithTimeOutputLayout(time.RFC3339), WithNormalizeTimeUTC(true)},
+			want:   "2021-03-03T22:06:07Z",
+		},
+		{
+			name:  "url value",
+			input: *urlVal,
+			want:  "https://example.com/path?q=1",
+		},
+		{
+			name:  "url pointer",
+			input: urlVal,
+			want:  "https://example.com/path?q=1",
+		},
+		{
+			name:  "ip",
+			input: net.ParseIP("10.0.0.1"),
+			want:  "10.0.0.1",
+		},
+		{
+			name:  "empty ip",
+			input: net.IP{},
+			want:  "",
+		},
+		{
+			name:  "raw bytes",
+			input: []byte("hi"),
+			want:  "hi",
+		},
+		{
+			name:   "hex bytes",
+			input:  []byte("hi"),
+			fnOpts: []FuncOption{WithBytesEncoding(BytesHex)},
+			want:   "6869",
+		},
+		{
+			name:   "base64 bytes",
+			input:  []byte("hi?"),
+			fnOpts: []FuncOption{WithBytesEncoding(BytesBase64)},
+			want:   "aGk/",
+		},
+		{
+			name:   "base64 URL bytes",
+			input:  []byte("hi?"),
+			fnOpts: []FuncOption{WithBytesEncoding(BytesBase64URL)},
+			want:   "aGk_",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractString(reflect.ValueOf(tt.input), tt.fnOpts...)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	type config struct {
+		Timeout time.Duration
+		At      time.Time
+		Data    []byte
+	}
+	opts := []FuncOption{WithBytesEncoding(BytesBase64)}
+	input := config{
+		Timeout: 90 * time.Minute,
+		At:      time.Date(2021, 3, 4, 5, 6, 7, 8, time.UTC),
+		Data:    []byte{0, 1, 2, 255},
+	}
+
+	var output config
+	inputVal := reflect.ValueOf(input)
+	for index := 0; index < inputVal.NumField(); index++ {
+		str, err := ExtractString(inputVal.Field(index), opts...)
+		assert.Nil(t, err)
+		err = AssignReflect(reflect.ValueOf(&output).Elem().Field(index), reflect.ValueOf(str), opts...)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, input.Timeout, output.Timeout)
+	assert.True(t, input.At.Equal(output.At))
+	assert.Equal(t, input.Data, output.Data)
+
+	layoutOpts := []FuncOption{WithTimeOutputLayout("02/01/2006 15:04")}
+	at := time.Date(2021, 3, 4, 5, 6, 0, 0, time.UTC)
+	str, err := ExtractString(reflect.ValueOf(at), layoutOpts...)
+	assert.Nil(t, err)
+	assert.Equal(t, "04/03/2021 05:06", str)
+	var atOutput time.Time
+	err = AssignReflect(reflect.ValueOf(&atOutput), reflect.ValueOf(str), layoutOpts...)
+	assert.Nil(t, err)
+	assert.True(t, at.Equal(atOutput))
+
+	var data []byte
+	err = AssignReflect(reflect.ValueOf(&data), reflect.ValueOf("zz"), WithBytesEncoding(BytesHex))
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+}
+
+func TestExtractNumberFromDuration(t *testing.T) {
+	dur := 90 * time.Minute
+	gotFloat, err := ExtractFloat(reflect.ValueOf(dur))
+	assert.Nil(t, err)
+	assert.Equal(t, 5.4e+12, gotFloat)
+
+	gotComplex, err := ExtractComplex(reflect.ValueOf(&dur))
+	assert.Nil(t, err)
+	assert.Equal(t, complex(5.4e+12, 0), gotComplex)
+
+	var res struct {
+		Float   float64
+		Complex complex64
+	}
+	err = AssignReflect(reflect.ValueOf(&res.Float), reflect.ValueOf(dur))
+	assert.Nil(t, err)
+	assert.Equal(t, 5.4e+12, res.Float)
+	err = AssignReflect(reflect.ValueOf(&res.Complex), reflect.ValueOf(dur))
+	assert.Nil(t, err)
+	assert.Equal(t, complex64(complex(5.4e+12, 0)), res.Complex)
+}
@@ -9,14 +9,29 @@ import (
 // List of all errors for reflecthelper.
 var (
 	ErrAssignerCantSet = errors.New("assigner doesn't have the ability to set the value")
+	ErrSkipChildren    = errors.New("skip the children of the current node")
+	ErrStopWalk        = errors.New("stop the walk")
+	ErrRequired        = errors.New("the value is required")
+	ErrInvalidValue    = errors.New("the value is invalid")
+	ErrExtractNil      = errors.New("can't extract value from nil")
+	ErrChannelTimeout  = errors.New("timeout while waiting to receive from the channel")
 )
 
-func getErrOverflow(val reflect.Value) (err error) {
-	err = fmt.Errorf(
+func getErrOverflowMessage(val reflect.Value) string {
+	return fmt.Sprintf(
 		"assigner encounters overflow %s, underlying val: %s",
 		GetKind(val),
 		val.String(),
 	)
+}
+
+func getErrOverflow(assigner reflect.Value, val reflect.Value) (err error) {
+	err = &OverflowError{newErrorContext(val, GetType(assigner), getErrOverflowMessage(assigner))}
+	return
+}
+
+func getErrOverflowExtract(val reflect.Value, dstType reflect.Type) (err error) {
+	err = &OverflowError{newErrorContext(val, dstType, getErrOverflowMessage(val))}
 	return
 }
 
@@ -24,13 +39,13 @@ func getErrUnassignable(assigner reflect.Value, val reflect.Value) (err error) {
 	assignerType := GetType(assigner)
 	valType := GetType(val)
 	if !valType.AssignableTo(assignerType) {
-		err = fmt.Errorf(
+		err = &UnassignableError{newErrorContext(val, assignerType, fmt.Sprintf(
 			"error unassignable for kind: %s with val of reflect.Value, kind: %s type: %s val: %s",
 			GetKind(assigner),
 			GetKind(val),
 			GetType(val),
 			val,
-		)
+		))}
 	}
 	return
 }
@@ -66,32 +81,44 @@ func getErrCanInterface(val reflect.Value) (err error) {
 
 func getErrIsValid(val reflect.Value) (err error) {
 	if !val.IsValid() {
-		err = fmt.Errorf(
+		err = &sentinelError{ErrInvalidValue, fmt.Sprintf(
 			"the val of reflect.Value is invalid, underlying val: %s",
 			val.String(),
-		)
+		)}
 	}
 	return
 }
 
 func getErrUnimplementedExtract(val reflect.Value) (err error) {
-	err = fmt.Errorf(
+	err = &UnimplementedError{newErrorContext(val, nil, fmt.Sprintf(
 		"error unimplemented extraction for val of reflect.Value,kind: %s type: %s val: %s",
 		GetKind(val),
 		GetType(val),
 		val,
-	)
+	))}
 	return
 }
 
 func getErrUnimplementedAssign(assigner reflect.Value, val reflect.Value) (err error) {
-	err = fmt.Errorf(
+	err = &UnimplementedError{newErrorContext(val, GetType(assigner), fmt.Sprintf(
 		"error unimplemented assignment for kind: %s with val of reflect.Value, kind: %s type: %s val: %s",
 		GetKind(assigner),
 		GetKind(val),
 		GetType(val),
 		val,
-	)
+	))}
+	return
+}
+
+// getErrParse wraps the cause returned from the parser to the ParseError, it returns nil if the cause is nil.
+func getErrParse(val reflect.Value, dstType reflect.Type, cause error) (err error) {
+	if cause == nil {
+		return
+	}
+
+	ctx := newErrorContext(val, dstType, cause.Error())
+	ctx.Err = cause
+	err = &ParseError{ctx}
 	return
 }
 
@@ -136,12 +163,196 @@ func checkExtractValid(val reflect.Value, opt *Option) (err error) {
 func getErrExtractNil(input interface{}) (err error) {
 	if IsNil(input) {
 		val := reflect.ValueOf(input)
-		err = fmt.Errorf(
+		err = &sentinelError{ErrExtractNil, fmt.Sprintf(
 			"error can't extract value from nil, type: %s kind: %s val: %s",
 			GetType(val),
 			GetKind(val),
 			val,
-		)
+		)}
+	}
+	return
+}
+
+func getErrInvalidPath(path string, position int) (err error) {
+	err = fmt.Errorf(
+		"error invalid path: %s at position: %d",
+		path,
+		position,
+	)
+	return
+}
+
+func getErrPathNotFound(path Path) (err error) {
+	err = fmt.Errorf(
+		"error path not found: %s",
+		path,
+	)
+	return
+}
+
+func getErrPathNil(path Path) (err error) {
+	err = fmt.Errorf(
+		"error can't lookup the path from nil or invalid value, path: %s",
+		path,
+	)
+	return
+}
+
+func getErrNotList(val reflect.Value) (err error) {
+	err = fmt.Errorf(
+		"error the val of reflect.Value is not an array or slice, kind: %s type: %s",
+		GetKind(val),
+		GetType(val),
+	)
+	return
+}
+
+func getErrUncomparableKey(typ reflect.Type) (err error) {
+	err = fmt.Errorf(
+		"error uncomparable type can't be used as a map key, type: %s",
+		typ,
+	)
+	return
+}
+
+func getErrIncomparable(left reflect.Value, right reflect.Value) (err error) {
+	err = fmt.Errorf(
+		"error incomparable values, left type: %s kind: %s, right type: %s kind: %s",
+		GetType(left),
+		GetKind(left),
+		GetType(right),
+		GetKind(right),
+	)
+	return
+}
+
+func getErrNaN(val reflect.Value) (err error) {
+	err = fmt.Errorf(
+		"error can't compare the non-finite number, type: %s val: %s",
+		GetType(val),
+		val,
+	)
+	return
+}
+
+func getErrExpressionDepth(expr string, position int, maxDepth int) (err error) {
+	err = getErrParse(
+		reflect.ValueOf(expr),
+		reflect.TypeOf(Expression{}),
+		getErrExpression(expr, position, fmt.Sprintf("nesting is deeper than %d", maxDepth)),
+	)
+	return
+}
+
+func getErrExpression(expr string, position int, msg string) (err error) {
+	err = fmt.Errorf(
+		"error invalid expression: %s at position: %d, expression: %s",
+		msg,
+		position,
+		expr,
+	)
+	return
+}
+
+func getErrUnknownRule(rule string) (err error) {
+	err = fmt.Errorf("error unknown validation rule: %s", rule)
+	return
+}
+
+var ruleDescriptions = map[string]string{
+	"min": "greater than or equal to",
+	"gte": "greater than or equal to",
+	"max": "less than or equal to",
+	"lte": "less than or equal to",
+	"gt":  "greater than",
+	"lt":  "less than",
+	"len": "equal to",
+	"eq":  "equal to",
+	"ne":  "not equal to",
+}
+
+func getErrRuleCompare(rule string, param string, isLength bool) (err error) {
+	subject := "value"
+	if isLength {
+		subject = "length"
 	}
+	err = fmt.Errorf("the %s must be %s %s", subject, ruleDescriptions[rule], param)
+	return
+}
+
+func getErrRuleOneOf(param string) (err error) {
+	err = fmt.Errorf("the value must be one of: %s", param)
+	return
+}
+
+func getErrRuleRegex(pattern string) (err error) {
+	err = fmt.Errorf("the value doesn't match the pattern: %s", pattern)
+	return
+}
+
+func getErrRuleFormat(format string) (err error) {
+	err = fmt.Errorf("the value is not a valid %s", format)
+	return
+}
+
+func getErrDefaultTag(path string, cause error) (err error) {
+	err = fmt.Errorf("error setting the default value of %s: %w", path, cause)
+	return
+}
+
+func getErrInvalidDefaultMap(pair string) (err error) {
+	err = fmt.Errorf("error invalid default map entry, expected key:value, got: %s", pair)
+	return
+}
+
+func getErrUnknownMergeStrategy(name string) (err error) {
+	err = fmt.Errorf("error unknown merge strategy: %s", name)
+	return
+}
+
+func getErrMergeField(field string, cause error) (err error) {
+	err = fmt.Errorf("error merging field %s: %w", field, cause)
+	return
+}
+
+func getErrInferTimeLayout(timeTxt string) (err error) {
+	err = fmt.Errorf("error can't infer the time layout of: %s", timeTxt)
+	return
+}
+
+func getErrInvalidDuration(durTxt string) (err error) {
+	err = fmt.Errorf("error invalid duration: %s", durTxt)
+	return
+}
+
+func getErrOverflowDuration(durTxt string) (err error) {
+	err = getErrOverflowExtract(reflect.ValueOf(durTxt), TypeDuration)
+	return
+}
+
+func getErrRelativeTime(timeTxt string) (err error) {
+	err = fmt.Errorf("error invalid relative time: %s", timeTxt)
+	return
+}
+
+func getErrStrict(val reflect.Value, dstType reflect.Type, policy StrictPolicy) (err error) {
+	err = &StrictError{newErrorContext(val, dstType, fmt.Sprintf(
+		"error strict mode disallows %s conversion, kind: %s type: %s val: %v dst type: %s",
+		policy,
+		GetKind(val),
+		GetType(val),
+		val,
+		dstType,
+	)), policy}
+	return
+}
+
+func getErrFractional(val reflect.Value, dstType reflect.Type) (err error) {
+	err = &ParseError{newErrorContext(val, dstType, fmt.Sprintf(
+		"error can't convert the fractional number to integer without the rounding mode, kind: %s type: %s val: %v",
+		GetKind(val),
+		GetType(val),
+		val,
+	))}
 	return
 }
@@ -0,0 +1,143 @@
+package reflecthelper
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ElemError is an error returned from the iteration function along with the location of the element.
+type ElemError struct {
+	// Kind is the kind of the iterated value, e.g. reflect.Struct or reflect.Map.
+	Kind reflect.Kind
+	// Field is the name of the struct field, only for reflect.Struct.
+	Field string
+	// Index is the index of the struct field, the array or slice element, or the received channel value.
+	Index int
+	// Key is the key of the map element, only for reflect.Map.
+	Key reflect.Value
+	// Err is the error returned from the iteration function.
+	Err error
+}
+
+// Location returns the location of the element as a string.
+func (e *ElemError) Location() string {
+	switch e.Kind {
+	case reflect.Struct:
+		return "field " + e.Field
+	case reflect.Map:
+		return "key " + formatPathKey(e.Key)
+	case reflect.Chan:
+		return "received value " + strconv.Itoa(e.Index)
+	}
+	return "index " + strconv.Itoa(e.Index)
+}
+
+// Error returns the error message of the element error.
+func (e *ElemError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Location(), e.Err)
+}
+
+// Unwrap returns the error returned from the iteration function.
+func (e *ElemError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError is a collection of errors returned as a single error.
+type MultiError []error
+
+// Error returns all of the error messages joined together.
+func (m MultiError) Error() string {
+	if len(m) == 1 {
+		return m[0].Error()
+	}
+
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(m), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns all of the errors contained in the MultiError.
+func (m MultiError) Unwrap() []error {
+	return m
+}
+
+// Is reports whether any error in the MultiError matches the target.
+func (m MultiError) Is(target error) bool {
+	for _, err := range m {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error in the MultiError that matches the target.
+func (m MultiError) As(target interface{}) bool {
+	for _, err := range m {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendUniqueError appends the err or the errors inside the MultiError that don't exist yet in the errs.
+func appendUniqueError(errs MultiError, err error) MultiError {
+	if multiErr, ok := err.(MultiError); ok {
+		for _, each := range multiErr {
+			errs = appendUniqueError(errs, each)
+		}
+		return errs
+	}
+	if err == nil {
+		return errs
+	}
+	for _, existing := range errs {
+		if errors.Is(existing, err) {
+			return errs
+		}
+	}
+	return append(errs, err)
+}
+
+type orderedError struct {
+	order int
+	err   error
+}
+
+type errorCollector struct {
+	mu   sync.Mutex
+	errs []orderedError
+}
+
+func (c *errorCollector) add(order int, err error) {
+	c.mu.Lock()
+	c.errs = append(c.errs, orderedError{order, err})
+	c.mu.Unlock()
+}
+
+func (c *errorCollector) error() (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.errs) == 0 {
+		return
+	}
+
+	sort.SliceStable(c.errs, func(i, j int) bool {
+		return c.errs[i].order < c.errs[j].order
+	})
+	multiErr := make(MultiError, 0, len(c.errs))
+	for _, orderedErr := range c.errs {
+		multiErr = append(multiErr, orderedErr.err)
+	}
+	err = multiErr
+	return
+}
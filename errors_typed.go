@@ -0,0 +1,157 @@
+package reflecthelper
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrorContext is the context of the errors returned from the assignment and the extraction of this package.
+type ErrorContext struct {
+	// SrcType is the type of the source value.
+	SrcType reflect.Type
+	// DstType is the type of the destination, it is nil if the destination is unknown.
+	DstType reflect.Type
+	// Kind is the kind of the Value.
+	Kind reflect.Kind
+	// Value is the source value causing the error.
+	Value reflect.Value
+	// Path is the location of the Value from the root value, it is empty if the location is unknown.
+	Path string
+	// Err is the underlying cause of the error, e.g. *strconv.NumError.
+	Err error
+
+	msg string
+}
+
+// Unwrap returns the underlying cause of the error.
+func (c ErrorContext) Unwrap() error {
+	return c.Err
+}
+
+func (c ErrorContext) message(name string) string {
+	if c.msg != "" {
+		return c.msg
+	}
+	return fmt.Sprintf("error %s, kind: %s src type: %s dst type: %s", name, c.Kind, c.SrcType, c.DstType)
+}
+
+func newErrorContext(val reflect.Value, dstType reflect.Type, msg string) ErrorContext {
+	return ErrorContext{
+		SrcType: GetType(val),
+		DstType: dstType,
+		Kind:    GetKind(val),
+		Value:   val,
+		msg:     msg,
+	}
+}
+
+// OverflowError is returned when the value overflows the destination type.
+type OverflowError struct {
+	ErrorContext
+}
+
+// Error returns the error message of the overflow error.
+func (e *OverflowError) Error() string {
+	return e.message("overflow")
+}
+
+// UnassignableError is returned when the value is not assignable to the destination type.
+type UnassignableError struct {
+	ErrorContext
+}
+
+// Error returns the error message of the unassignable error.
+func (e *UnassignableError) Error() string {
+	return e.message("unassignable")
+}
+
+// UnimplementedError is returned when the conversion between the types is not implemented.
+type UnimplementedError struct {
+	ErrorContext
+}
+
+// Error returns the error message of the unimplemented error.
+func (e *UnimplementedError) Error() string {
+	return e.message("unimplemented")
+}
+
+// ParseError is returned when the value can't be parsed to the destination type.
+// The Err is the error returned from the parser, e.g. *strconv.NumError or *time.ParseError.
+type ParseError struct {
+	ErrorContext
+}
+
+// Error returns the error message of the parse error.
+func (e *ParseError) Error() string {
+	return e.message("parse")
+}
+
+// StrictError is returned when the conversion violates the policy set by WithStrictMode.
+type StrictError struct {
+	ErrorContext
+	// Policy is the bitmask of all enabled policies violated by the conversion.
+	Policy StrictPolicy
+}
+
+// Error returns the error message of the strict error.
+func (e *StrictError) Error() string {
+	return e.message("strict " + e.Policy.String())
+}
+
+// sentinelError is an error with a custom message matching the sentinel using errors.Is.
+type sentinelError struct {
+	sentinel error
+	msg      string
+}
+
+func (e *sentinelError) Error() string {
+	return e.msg
+}
+
+func (e *sentinelError) Unwrap() error {
+	return e.sentinel
+}
+
+// getErrorContext gets the context of the outermost typed error of this package in the chain of err.
+func getErrorContext(err error) (res *ErrorContext) {
+	for ; err != nil && res == nil; err = errors.Unwrap(err) {
+		res = getTypedErrorContext(err)
+	}
+	return
+}
+
+func getTypedErrorContext(err error) (res *ErrorContext) {
+	switch typedErr := err.(type) {
+	case *OverflowError:
+		res = &typedErr.ErrorContext
+	case *UnassignableError:
+		res = &typedErr.ErrorContext
+	case *UnimplementedError:
+		res = &typedErr.ErrorContext
+	case *ParseError:
+		res = &typedErr.ErrorContext
+	case *StrictError:
+		res = &typedErr.ErrorContext
+	}
+	return
+}
+
+// setErrorPath prepends the segment to the path of the typed error of this package.
+func setErrorPath(err error, segment string) error {
+	ctx := getErrorContext(err)
+	if ctx == nil {
+		return err
+	}
+
+	switch {
+	case ctx.Path == "":
+		ctx.Path = segment
+	case strings.HasPrefix(ctx.Path, "["):
+		ctx.Path = segment + ctx.Path
+	default:
+		ctx.Path = joinFieldPath(segment, ctx.Path)
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+package reflecthelper
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedErrors(t *testing.T) {
+	t.Run("overflow", func(t *testing.T) {
+		var res int8
+		err := AssignReflect(reflect.ValueOf(&res), reflect.ValueOf(300))
+		var overflowErr *OverflowError
+		assert.True(t, errors.As(err, &overflowErr))
+		assert.Equal(t, "assigner encounters overflow int8, underlying val: <int8 Value>", err.Error())
+		assert.Equal(t, reflect.TypeOf(0), overflowErr.SrcType)
+		assert.Equal(t, reflect.TypeOf(int8(0)), overflowErr.DstType)
+		assert.Equal(t, reflect.Int, overflowErr.Kind)
+		assert.Equal(t, 300, overflowErr.Value.Interface())
+
+		_, err = ExtractUint(reflect.ValueOf(-1))
+		assert.True(t, errors.As(err, &overflowErr))
+		assert.Equal(t, TypeUint64, overflowErr.DstType)
+	})
+
+	t.Run("overflow path", func(t *testing.T) {
+		var res []int8
+		err := AssignReflect(reflect.ValueOf(&res), reflect.ValueOf([]int{1, 300}))
+		var overflowErr *OverflowError
+		assert.True(t, errors.As(err, &overflowErr))
+		assert.Equal(t, "[1]", overflowErr.Path)
+
+		type input struct {
+			Small int8 `default:"300"`
+		}
+		err = SetDefaults(&input{})
+		assert.True(t, errors.As(err, &overflowErr))
+		assert.Equal(t, "Small", overflowErr.Path)
+	})
+
+	t.Run("nested struct path", func(t *testing.T) {
+		var dst struct {
+			In struct {
+				X int8
+			}
+		}
+		err := Merge(&dst, map[string]interface{}{"In": map[string]interface{}{"X": 1000}})
+		var overflowErr *OverflowError
+		assert.True(t, errors.As(err, &overflowErr))
+		assert.Equal(t, "In.X", overflowErr.Path)
+
+		type inner struct {
+			List []int8
+		}
+		var nested struct {
+			Outer struct {
+				Inner inner
+			}
+		}
+		err = Merge(&nested, map[string]interface{}{
+			"Outer": map[string]interface{}{"Inner": map[string]interface{}{"List": []int{1, 300}}},
+		})
+		assert.True(t, errors.As(err, &overflowErr))
+		assert.Equal(t, "Outer.Inner.List[1]", overflowErr.Path)
+	})
+
+	t.Run("unimplemented", func(t *testing.T) {
+		var res []string
+		err := AssignReflect(reflect.ValueOf(&res), reflect.ValueOf("abc"))
+		var unimplementedErr *UnimplementedError
+		assert.True(t, errors.As(err, &unimplementedErr))
+		assert.Equal(t, reflect.TypeOf([]string{}), unimplementedErr.DstType)
+		assert.Equal(t, reflect.String, unimplementedErr.Kind)
+		assert.Contains(t, err.Error(), "error unimplemented assignment for kind: slice")
+	})
+
+	t.Run("unassignable", func(t *testing.T) {
+		err := getErrUnassignable(reflect.ValueOf(0), reflect.ValueOf("abc"))
+		var unassignableErr *UnassignableError
+		assert.True(t, errors.As(err, &unassignableErr))
+		assert.Equal(t, reflect.TypeOf(""), unassignableErr.SrcType)
+		assert.Equal(t, reflect.TypeOf(0), unassignableErr.DstType)
+		assert.Equal(t, "error unassignable for kind: int with val of reflect.Value, kind: string type: string val: abc", err.Error())
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		_, err := ExtractInt(reflect.ValueOf("abc"))
+		var parseErr *ParseError
+		assert.True(t, errors.As(err, &parseErr))
+		assert.Equal(t, TypeInt64, parseErr.DstType)
+		assert.Equal(t, `strconv.ParseInt: parsing "abc": invalid syntax`, err.Error())
+
+		var numErr *strconv.NumError
+		assert.True(t, errors.As(err, &numErr))
+		assert.True(t, errors.Is(err, strconv.ErrSyntax))
+
+		_, err = ExtractTime(reflect.ValueOf("abc"))
+		assert.True(t, errors.As(err, &parseErr))
+		var timeErr *time.ParseError
+		assert.True(t, errors.As(err, &timeErr))
+	})
+
+	t.Run("sentinel", func(t *testing.T) {
+		_, err := ExtractInt(reflect.Value{})
+		assert.True(t, errors.Is(err, ErrInvalidValue))
+		assert.Equal(t, "the val of reflect.Value is invalid, underlying val: <invalid Value>", err.Error())
+
+		_, err = castString(nil)
+		assert.True(t, errors.Is(err, ErrExtractNil))
+	})
+
+	t.Run("zero value message", func(t *testing.T) {
+		err := &ParseError{ErrorContext{Kind: reflect.String, SrcType: reflect.TypeOf(""), DstType: TypeInt64}}
+		assert.Equal(t, "error parse, kind: string src type: string dst type: int64", err.Error())
+		assert.Nil(t, errors.Unwrap(err))
+	})
+}
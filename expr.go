@@ -0,0 +1,716 @@
+package reflecthelper
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type exprTokenKind uint8
+
+const (
+	exprTokenEOF exprTokenKind = iota
+	exprTokenPath
+	exprTokenNumber
+	exprTokenString
+	exprTokenOperator
+	exprTokenKeyword
+)
+
+type exprToken struct {
+	kind     exprTokenKind
+	text     string
+	position int
+}
+
+var exprKeywords = map[string]struct{}{
+	"and":        {},
+	"or":         {},
+	"not":        {},
+	"in":         {},
+	"contains":   {},
+	"startsWith": {},
+	"endsWith":   {},
+	"matches":    {},
+	"true":       {},
+	"false":      {},
+	"null":       {},
+	"nil":        {},
+}
+
+var exprOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ",", "-"}
+
+func isExprIdentStart(char rune) bool {
+	return char == '_' || unicode.IsLetter(char)
+}
+
+func isExprIdentPart(char rune) bool {
+	return isExprIdentStart(char) || unicode.IsDigit(char)
+}
+
+type exprLexer struct {
+	input    string
+	position int
+	tokens   []exprToken
+}
+
+func tokenizeExpr(input string) (res []exprToken, err error) {
+	lexer := &exprLexer{input: input}
+	for {
+		var token exprToken
+		token, err = lexer.next()
+		if err != nil {
+			return
+		}
+		lexer.tokens = append(lexer.tokens, token)
+		if token.kind == exprTokenEOF {
+			break
+		}
+	}
+	res = lexer.tokens
+	return
+}
+
+// peekRune decodes the rune at the position of the input, the width is 0 at the end of the input.
+func (l *exprLexer) peekRune(position int) (char rune, width int) {
+	if position >= len(l.input) {
+		return
+	}
+	char, width = utf8.DecodeRuneInString(l.input[position:])
+	return
+}
+
+func (l *exprLexer) isIdentStartAt(position int) bool {
+	char, width := l.peekRune(position)
+	return width > 0 && isExprIdentStart(char)
+}
+
+func (l *exprLexer) next() (res exprToken, err error) {
+	for char, width := l.peekRune(l.position); width > 0 && unicode.IsSpace(char); char, width = l.peekRune(l.position) {
+		l.position += width
+	}
+	res.position = l.position
+	if l.position >= len(l.input) {
+		res.kind = exprTokenEOF
+		return
+	}
+
+	char, _ := l.peekRune(l.position)
+	switch {
+	case char == '"' || char == '\'':
+		res.kind = exprTokenString
+		res.text, err = l.readString()
+	case isDigit(l.input[l.position]) || (char == '.' && l.position+1 < len(l.input) && isDigit(l.input[l.position+1])):
+		res.kind = exprTokenNumber
+		res.text = l.readNumber()
+	case isExprIdentStart(char):
+		res.text = l.readIdent()
+		if _, ok := exprKeywords[res.text]; ok {
+			res.kind = exprTokenKeyword
+			return
+		}
+		res.kind = exprTokenPath
+		res.text, err = l.readPath(res.text)
+	default:
+		for _, operator := range exprOperators {
+			if strings.HasPrefix(l.input[l.position:], operator) {
+				res.kind = exprTokenOperator
+				res.text = operator
+				l.position += len(operator)
+				return
+			}
+		}
+		err = getErrExpression(l.input, l.position, "unexpected character")
+	}
+	return
+}
+
+func (l *exprLexer) readString() (res string, err error) {
+	start := l.position
+	quote := l.input[l.position]
+	var builder strings.Builder
+	for l.position++; l.position < len(l.input); l.position++ {
+		char := l.input[l.position]
+		switch {
+		case char == '\\' && l.position+1 < len(l.input):
+			l.position++
+			builder.WriteByte(l.input[l.position])
+		case char == quote:
+			l.position++
+			res = builder.String()
+			return
+		default:
+			builder.WriteByte(char)
+		}
+	}
+	err = getErrExpression(l.input, start, "unterminated string")
+	return
+}
+
+func (l *exprLexer) readNumber() string {
+	start := l.position
+	for l.position < len(l.input) {
+		char := l.input[l.position]
+		isExponentSign := (char == '+' || char == '-') && (l.input[l.position-1] == 'e' || l.input[l.position-1] == 'E')
+		if !isDigit(char) && char != '.' && char != 'e' && char != 'E' && !isExponentSign {
+			break
+		}
+		l.position++
+	}
+	return l.input[start:l.position]
+}
+
+func (l *exprLexer) readIdent() string {
+	start := l.position
+	for char, width := l.peekRune(l.position); width > 0 && isExprIdentPart(char); char, width = l.peekRune(l.position) {
+		l.position += width
+	}
+	return l.input[start:l.position]
+}
+
+// readPath continues reading the path after the first identifier, e.g. profile.country or tags[0].
+func (l *exprLexer) readPath(ident string) (res string, err error) {
+	start := l.position - len(ident)
+	for l.position < len(l.input) {
+		switch char := l.input[l.position]; {
+		case char == '.' && l.isIdentStartAt(l.position+1):
+			l.position++
+			l.readIdent()
+		case char == '[':
+			end := l.findBracketEnd()
+			if end < 0 {
+				err = getErrExpression(l.input, l.position, "unclosed bracket")
+				return
+			}
+			l.position = end + 1
+		default:
+			res = l.input[start:l.position]
+			return
+		}
+	}
+	res = l.input[start:l.position]
+	return
+}
+
+func (l *exprLexer) findBracketEnd() int {
+	var quote byte
+	for position := l.position + 1; position < len(l.input); position++ {
+		char := l.input[position]
+		switch {
+		case quote != 0 && char == quote:
+			quote = 0
+		case quote != 0:
+		case char == '"' || char == '\'':
+			quote = char
+		case char == ']':
+			return position
+		}
+	}
+	return -1
+}
+
+type exprContext struct {
+	root reflect.Value
+	opt  *Option
+}
+
+type exprNode interface {
+	eval(ctx *exprContext) (reflect.Value, error)
+}
+
+type exprLiteral struct {
+	val reflect.Value
+}
+
+func (n *exprLiteral) eval(ctx *exprContext) (reflect.Value, error) {
+	return n.val, nil
+}
+
+type exprPath struct {
+	path Path
+}
+
+// eval returns an invalid reflect.Value, like null, if the path can't be resolved.
+func (n *exprPath) eval(ctx *exprContext) (res reflect.Value, err error) {
+	res, err = lookupPath(ctx.root, n.path, ctx.opt)
+	if err != nil {
+		res, err = reflect.Value{}, nil
+	}
+	return
+}
+
+type exprList struct {
+	items []exprNode
+}
+
+func (n *exprList) eval(ctx *exprContext) (res reflect.Value, err error) {
+	list := make([]interface{}, 0, len(n.items))
+	for _, item := range n.items {
+		var val reflect.Value
+		val, err = item.eval(ctx)
+		if err != nil {
+			return
+		}
+		var elem interface{}
+		if val.IsValid() && val.CanInterface() {
+			elem = val.Interface()
+		}
+		list = append(list, elem)
+	}
+	res = reflect.ValueOf(list)
+	return
+}
+
+type exprNot struct {
+	operand exprNode
+}
+
+func (n *exprNot) eval(ctx *exprContext) (res reflect.Value, err error) {
+	truth, err := evalExprBool(n.operand, ctx)
+	if err != nil {
+		return
+	}
+	res = reflect.ValueOf(!truth)
+	return
+}
+
+type exprLogical struct {
+	isAnd bool
+	left  exprNode
+	right exprNode
+}
+
+func (n *exprLogical) eval(ctx *exprContext) (res reflect.Value, err error) {
+	truth, err := evalExprBool(n.left, ctx)
+	if err != nil {
+		return
+	}
+	if truth != n.isAnd {
+		res = reflect.ValueOf(truth)
+		return
+	}
+	truth, err = evalExprBool(n.right, ctx)
+	if err != nil {
+		return
+	}
+	res = reflect.ValueOf(truth)
+	return
+}
+
+type exprBinary struct {
+	operator string
+	negate   bool
+	left     exprNode
+	right    exprNode
+	regex    *regexp.Regexp
+}
+
+func (n *exprBinary) eval(ctx *exprContext) (res reflect.Value, err error) {
+	left, err := n.left.eval(ctx)
+	if err != nil {
+		return
+	}
+	right, err := n.right.eval(ctx)
+	if err != nil {
+		return
+	}
+
+	var truth bool
+	switch n.operator {
+	case "==", "!=":
+		truth = isExprEqual(left, right, ctx.opt)
+		if n.operator == "!=" {
+			truth = !truth
+		}
+	case "<", "<=", ">", ">=":
+		truth, err = evalExprOrder(n.operator, left, right, ctx.opt)
+	case "contains":
+		truth, err = evalExprContains(left, right, ctx.opt)
+	case "in":
+		truth, err = evalExprContains(right, left, ctx.opt)
+	case "startsWith", "endsWith":
+		truth, err = evalExprAffix(n.operator, left, right, ctx.opt)
+	case "matches":
+		truth, err = n.evalMatches(left, right, ctx.opt)
+	}
+	if err != nil {
+		return
+	}
+	if n.negate {
+		truth = !truth
+	}
+	res = reflect.ValueOf(truth)
+	return
+}
+
+func (n *exprBinary) evalMatches(left reflect.Value, right reflect.Value, opt *Option) (res bool, err error) {
+	regex := n.regex
+	if regex == nil {
+		var pattern string
+		pattern, err = extractString(right, opt.resetCheck())
+		if err != nil {
+			return
+		}
+		regex, err = regexp.Compile(pattern)
+		if err != nil {
+			return
+		}
+	}
+
+	str, err := extractString(left, opt.resetCheck())
+	if err != nil {
+		err = nil
+		return
+	}
+	res = regex.MatchString(str)
+	return
+}
+
+func evalExprBool(node exprNode, ctx *exprContext) (res bool, err error) {
+	val, err := node.eval(ctx)
+	if err != nil {
+		return
+	}
+
+	val = GetChildElem(val)
+	if isCompareNil(val) {
+		return
+	}
+	res, err = extractBool(val, ctx.opt.resetCheck())
+	return
+}
+
+func isExprEqual(left reflect.Value, right reflect.Value, opt *Option) bool {
+	res, err := compareValues(left, right, opt)
+	if err == nil {
+		return res == 0
+	}
+
+	left, right = GetChildElem(left), GetChildElem(right)
+	return left.CanInterface() && right.CanInterface() && reflect.DeepEqual(left.Interface(), right.Interface())
+}
+
+func evalExprOrder(operator string, left reflect.Value, right reflect.Value, opt *Option) (res bool, err error) {
+	if isCompareNil(GetChildElem(left)) || isCompareNil(GetChildElem(right)) {
+		return
+	}
+
+	cmp, err := compareValues(left, right, opt)
+	if err != nil {
+		return
+	}
+	switch operator {
+	case "<":
+		res = cmp < 0
+	case "<=":
+		res = cmp <= 0
+	case ">":
+		res = cmp > 0
+	case ">=":
+		res = cmp >= 0
+	}
+	return
+}
+
+// evalExprContains checks whether the container contains the item.
+// The container can be a string, an array or slice, or a map for checking the key.
+func evalExprContains(container reflect.Value, item reflect.Value, opt *Option) (res bool, err error) {
+	container = GetChildElem(container)
+	switch GetKind(container) {
+	case reflect.String:
+		var str string
+		str, err = extractString(item, opt.resetCheck())
+		if err != nil {
+			err = nil
+			return
+		}
+		res = strings.Contains(container.String(), str)
+	case reflect.Array, reflect.Slice:
+		for index := 0; index < container.Len(); index++ {
+			if isExprEqual(container.Index(index), item, opt) {
+				res = true
+				return
+			}
+		}
+	case reflect.Map:
+		key := reflect.New(container.Type().Key()).Elem()
+		if assignReflect(key, item, opt) != nil {
+			return
+		}
+		res = container.MapIndex(key).IsValid()
+	}
+	return
+}
+
+func evalExprAffix(operator string, left reflect.Value, right reflect.Value, opt *Option) (res bool, err error) {
+	str, err := extractString(left, opt.resetCheck())
+	if err != nil {
+		err = nil
+		return
+	}
+	affix, err := extractString(right, opt.resetCheck())
+	if err != nil {
+		err = nil
+		return
+	}
+	if operator == "startsWith" {
+		res = strings.HasPrefix(str, affix)
+		return
+	}
+	res = strings.HasSuffix(str, affix)
+	return
+}
+
+// maxExprDepth is the maximum nesting depth of the parentheses, the lists, and the negations in the expression.
+const maxExprDepth = 100
+
+type exprParser struct {
+	input    string
+	tokens   []exprToken
+	position int
+	depth    int
+}
+
+func parseExpr(input string) (res exprNode, err error) {
+	tokens, err := tokenizeExpr(input)
+	if err != nil {
+		return
+	}
+
+	parser := &exprParser{input: input, tokens: tokens}
+	res, err = parser.parseOr()
+	if err != nil {
+		return
+	}
+	if token := parser.peek(); token.kind != exprTokenEOF {
+		err = getErrExpression(input, token.position, "unexpected token "+strconv.Quote(token.text))
+	}
+	return
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.position]
+}
+
+func (p *exprParser) advance() (res exprToken) {
+	res = p.tokens[p.position]
+	if res.kind != exprTokenEOF {
+		p.position++
+	}
+	return
+}
+
+func (p *exprParser) isToken(kind exprTokenKind, texts ...string) bool {
+	token := p.peek()
+	if token.kind != kind {
+		return false
+	}
+	for _, text := range texts {
+		if token.text == text {
+			return true
+		}
+	}
+	return false
+}
+
+// enter increases the nesting depth, the caller must call leave after parsing the nested expression.
+func (p *exprParser) enter(token exprToken) (err error) {
+	p.depth++
+	if p.depth > maxExprDepth {
+		err = getErrExpressionDepth(p.input, token.position, maxExprDepth)
+	}
+	return
+}
+
+func (p *exprParser) leave() {
+	p.depth--
+}
+
+func (p *exprParser) expect(text string) (err error) {
+	if !p.isToken(exprTokenOperator, text) {
+		token := p.peek()
+		err = getErrExpression(p.input, token.position, "expected "+strconv.Quote(text))
+		return
+	}
+	p.advance()
+	return
+}
+
+func (p *exprParser) parseOr() (res exprNode, err error) {
+	res, err = p.parseAnd()
+	for err == nil && (p.isToken(exprTokenOperator, "||") || p.isToken(exprTokenKeyword, "or")) {
+		p.advance()
+		var right exprNode
+		right, err = p.parseAnd()
+		res = &exprLogical{left: res, right: right}
+	}
+	return
+}
+
+func (p *exprParser) parseAnd() (res exprNode, err error) {
+	res, err = p.parseNot()
+	for err == nil && (p.isToken(exprTokenOperator, "&&") || p.isToken(exprTokenKeyword, "and")) {
+		p.advance()
+		var right exprNode
+		right, err = p.parseNot()
+		res = &exprLogical{isAnd: true, left: res, right: right}
+	}
+	return
+}
+
+func (p *exprParser) parseNot() (res exprNode, err error) {
+	if p.isToken(exprTokenOperator, "!") || p.isToken(exprTokenKeyword, "not") {
+		err = p.enter(p.advance())
+		defer p.leave()
+		if err != nil {
+			return
+		}
+
+		var operand exprNode
+		operand, err = p.parseNot()
+		res = &exprNot{operand: operand}
+		return
+	}
+	res, err = p.parseComparison()
+	return
+}
+
+func (p *exprParser) parseComparison() (res exprNode, err error) {
+	res, err = p.parseOperand()
+	if err != nil {
+		return
+	}
+
+	var negate bool
+	if p.isToken(exprTokenKeyword, "not") {
+		p.advance()
+		negate = true
+		if !p.isToken(exprTokenKeyword, "in", "contains", "startsWith", "endsWith", "matches") {
+			token := p.peek()
+			err = getErrExpression(p.input, token.position, "expected an operator after not")
+			return
+		}
+	}
+	if !p.isToken(exprTokenOperator, "==", "!=", "<", "<=", ">", ">=") &&
+		!p.isToken(exprTokenKeyword, "in", "contains", "startsWith", "endsWith", "matches") {
+		return
+	}
+
+	operator := p.advance()
+	right, err := p.parseOperand()
+	if err != nil {
+		return
+	}
+	binary := &exprBinary{operator: operator.text, negate: negate, left: res, right: right}
+	if literal, ok := right.(*exprLiteral); ok && operator.text == "matches" {
+		binary.regex, err = regexp.Compile(literal.val.String())
+		if err != nil {
+			err = getErrExpression(p.input, operator.position, err.Error())
+			return
+		}
+	}
+	res = binary
+	return
+}
+
+func (p *exprParser) parseOperand() (res exprNode, err error) {
+	token := p.advance()
+	switch token.kind {
+	case exprTokenNumber:
+		res, err = p.parseNumber(token, false)
+	case exprTokenString:
+		res = &exprLiteral{val: reflect.ValueOf(token.text)}
+	case exprTokenPath:
+		var path Path
+		path, err = ParsePath(token.text)
+		if err != nil {
+			return
+		}
+		res = &exprPath{path: path}
+	case exprTokenKeyword:
+		switch token.text {
+		case "true", "false":
+			res = &exprLiteral{val: reflect.ValueOf(token.text == "true")}
+		case "null", "nil":
+			res = &exprLiteral{}
+		default:
+			err = getErrExpression(p.input, token.position, "unexpected keyword "+strconv.Quote(token.text))
+		}
+	case exprTokenOperator:
+		if token.text == "(" || token.text == "[" {
+			err = p.enter(token)
+			defer p.leave()
+			if err != nil {
+				return
+			}
+		}
+		switch token.text {
+		case "(":
+			res, err = p.parseOr()
+			if err != nil {
+				return
+			}
+			err = p.expect(")")
+		case "[":
+			res, err = p.parseList()
+		case "-":
+			numberToken := p.advance()
+			if numberToken.kind != exprTokenNumber {
+				err = getErrExpression(p.input, numberToken.position, "expected a number after -")
+				return
+			}
+			res, err = p.parseNumber(numberToken, true)
+		default:
+			err = getErrExpression(p.input, token.position, "unexpected operator "+strconv.Quote(token.text))
+		}
+	default:
+		err = getErrExpression(p.input, token.position, "unexpected end of expression")
+	}
+	return
+}
+
+func (p *exprParser) parseNumber(token exprToken, negative bool) (res exprNode, err error) {
+	text := token.text
+	if negative {
+		text = "-" + text
+	}
+	if intVal, intErr := strconv.ParseInt(text, 10, 64); intErr == nil {
+		res = &exprLiteral{val: reflect.ValueOf(intVal)}
+		return
+	}
+
+	floatVal, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		err = getErrExpression(p.input, token.position, "invalid number "+strconv.Quote(text))
+		return
+	}
+	res = &exprLiteral{val: reflect.ValueOf(floatVal)}
+	return
+}
+
+func (p *exprParser) parseList() (res exprNode, err error) {
+	list := &exprList{}
+	if p.isToken(exprTokenOperator, "]") {
+		p.advance()
+		res = list
+		return
+	}
+	for {
+		var item exprNode
+		item, err = p.parseOr()
+		if err != nil {
+			return
+		}
+		list.items = append(list.items, item)
+		if !p.isToken(exprTokenOperator, ",") {
+			break
+		}
+		p.advance()
+	}
+	err = p.expect("]")
+	res = list
+	return
+}
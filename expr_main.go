@@ -0,0 +1,73 @@
+package reflecthelper
+
+import (
+	"reflect"
+)
+
+// Expression is a compiled filter expression that can be matched against Go values.
+// Expression is safe for concurrent use.
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+// CompileExpression compiles the filter expression, e.g. `age >= 18 && tags contains 'admin'`.
+// Reuse the compiled *Expression to avoid compiling the same expression repeatedly.
+//
+// The supported operators are ==, !=, <, <=, >, >=, &&, ||, !, and, or, not,
+// contains, in, startsWith, endsWith, and matches (regular expression).
+// The contains, in, startsWith, endsWith, and matches operators can be negated using not, e.g. `role not in ['guest']`.
+// The operands are paths resolved using LookupPath, strings quoted by double or single quote,
+// numbers, true, false, null, and lists, e.g. ['ID', 'MY'].
+// The paths that can't be resolved are evaluated as null.
+// The values are compared using Compare and coerced using the extractors of this package.
+// The parentheses, lists, and negations can be nested up to 100 levels, the deeper expression returns *ParseError.
+func CompileExpression(expr string) (res *Expression, err error) {
+	root, err := parseExpr(expr)
+	if err != nil {
+		return
+	}
+	res = &Expression{source: expr, root: root}
+	return
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Match evaluates the expression against the input and returns the result as bool.
+// The input can be an interface{} or a reflect.Value.
+func (e *Expression) Match(input interface{}, fnOpts ...FuncOption) (res bool, err error) {
+	opt := NewOption().Assign(fnOpts...)
+	defer recoverFnOpt(&err, opt)
+
+	res, err = evalExprBool(e.root, &exprContext{root: getValFromInterface(input), opt: opt})
+	return
+}
+
+// Match compiles the expression and evaluates it against the input.
+// See CompileExpression for the syntax of the expression.
+// Use CompileExpression to evaluate the same expression repeatedly.
+func Match(input interface{}, expr string, fnOpts ...FuncOption) (res bool, err error) {
+	compiled, err := CompileExpression(expr)
+	if err != nil {
+		return
+	}
+
+	res, err = compiled.Match(input, fnOpts...)
+	return
+}
+
+// FilterMatch returns a new slice containing the elements of the input array or slice matching the expression.
+func FilterMatch(input interface{}, expr string, fnOpts ...FuncOption) (res reflect.Value, err error) {
+	compiled, err := CompileExpression(expr)
+	if err != nil {
+		return
+	}
+
+	res, err = FilterSlice(input, func(index int, elem reflect.Value) (bool, error) {
+		return compiled.Match(elem, fnOpts...)
+	}, fnOpts...)
+	return
+}
@@ -0,0 +1,125 @@
+package reflecthelper
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type exprProfile struct {
+	Country string
+	Score   float64
+}
+
+type exprUser struct {
+	Name    string
+	Age     int
+	Active  bool
+	Tags    []string
+	Roles   map[string]int
+	Profile *exprProfile
+}
+
+func TestMatch(t *testing.T) {
+	user := exprUser{
+		Name:    "Budi Santoso",
+		Age:     21,
+		Active:  true,
+		Tags:    []string{"admin", "staff"},
+		Roles:   map[string]int{"owner": 1},
+		Profile: &exprProfile{Country: "ID", Score: 7.5},
+	}
+	tests := []struct {
+		name    string
+		expr    string
+		input   interface{}
+		want    bool
+		wantErr bool
+	}{
+		{name: "request example", expr: "age >= 18 && tags contains 'admin' && profile.country in ['ID','MY']", input: user, want: true},
+		{name: "pointer input", expr: "Age == 21", input: &user, want: true},
+		{name: "reflect value input", expr: "Age == 21", input: reflect.ValueOf(user), want: true},
+		{name: "coerced string number", expr: "age > '20'", input: user, want: true},
+		{name: "float comparison", expr: "profile.score < 7.6 and profile.score >= 7.5", input: user, want: true},
+		{name: "negative number", expr: "age > -1", input: user, want: true},
+		{name: "or", expr: "age < 18 || active", input: user, want: true},
+		{name: "not", expr: "!active", input: user, want: false},
+		{name: "not keyword with parentheses", expr: "not (age < 18 or name == 'x')", input: user, want: true},
+		{name: "not in", expr: "profile.country not in ['SG', 'MY']", input: user, want: true},
+		{name: "not contains", expr: "tags not contains 'guest'", input: user, want: true},
+		{name: "string contains", expr: "name contains 'Santoso'", input: user, want: true},
+		{name: "map contains key", expr: "roles contains 'owner'", input: user, want: true},
+		{name: "map missing key", expr: "roles contains 'guest'", input: user, want: false},
+		{name: "index path", expr: "tags[1] == \"staff\"", input: user, want: true},
+		{name: "starts and ends with", expr: "name startsWith 'Budi' && name endsWith 'oso'", input: user, want: true},
+		{name: "matches", expr: "name matches '^B\\\\w+ S'", input: user, want: true},
+		{name: "missing path is null", expr: "unknown == null", input: user, want: true},
+		{name: "missing path ordering", expr: "unknown > 1", input: user, want: false},
+		{name: "nil pointer path", expr: "profile.country == nil", input: exprUser{}, want: true},
+		{name: "unicode identifier", expr: "café > 1 && straße.länge == 2", input: map[string]interface{}{"café": 2, "straße": map[string]int{"länge": 2}}, want: true},
+		{name: "unicode string", expr: "name == 'Zoë'", input: map[string]string{"name": "Zoë"}, want: true},
+		{name: "map input", expr: "status == 'ok' && count >= 2", input: map[string]interface{}{"status": "ok", "count": "2"}, want: true},
+		{name: "empty list", expr: "age in []", input: user, want: false},
+		{name: "incomparable ordering", expr: "tags > 1", input: user, wantErr: true},
+		{name: "non bool result", expr: "name", input: user, wantErr: true},
+		{name: "syntax error", expr: "age >=", input: user, wantErr: true},
+		{name: "unterminated string", expr: "name == 'abc", input: user, wantErr: true},
+		{name: "unclosed list", expr: "age in [1, 2", input: user, wantErr: true},
+		{name: "trailing token", expr: "age == 1 2", input: user, wantErr: true},
+		{name: "invalid regex", expr: "name matches '('", input: user, wantErr: true},
+		{name: "unexpected character", expr: "age # 1", input: user, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Match(tt.input, tt.expr)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompileExpression(t *testing.T) {
+	expr, err := CompileExpression("age >= 18")
+	assert.Nil(t, err)
+	assert.Equal(t, "age >= 18", expr.String())
+
+	matched, err := expr.Match(map[string]int{"age": 20})
+	assert.Nil(t, err)
+	assert.True(t, matched)
+
+	_, err = CompileExpression("age >= ")
+	assert.NotNil(t, err)
+}
+
+func TestCompileExpressionDepth(t *testing.T) {
+	nested := strings.Repeat("(", maxExprDepth) + "true" + strings.Repeat(")", maxExprDepth)
+	_, err := CompileExpression(nested)
+	assert.Nil(t, err)
+
+	var parseErr *ParseError
+	for _, expr := range []string{
+		strings.Repeat("(", maxExprDepth+1) + "true" + strings.Repeat(")", maxExprDepth+1),
+		strings.Repeat("!", 100000) + "true",
+		"a in " + strings.Repeat("[", 100000),
+	} {
+		_, err = CompileExpression(expr)
+		assert.True(t, errors.As(err, &parseErr))
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	users := []exprUser{
+		{Name: "a", Age: 17},
+		{Name: "b", Age: 30, Tags: []string{"admin"}},
+		{Name: "c", Age: 40},
+	}
+	res, err := FilterMatch(users, "age >= 18 && tags contains 'admin'")
+	assert.Nil(t, err)
+	assert.Equal(t, []exprUser{users[1]}, res.Interface())
+
+	_, err = FilterMatch(users, "age >=")
+	assert.NotNil(t, err)
+}
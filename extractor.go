@@ -6,13 +6,20 @@ import (
 	"reflect"
 	"strconv"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 func extractBool(val reflect.Value, option *Option) (result bool, err error) {
+	val = exposeValueOpt(val, option)
 	err = checkExtractValid(val, option)
 	if err != nil {
 		return
 	}
+	err = checkStrict(val, TypeBool, option)
+	if err != nil {
+		return
+	}
 
 	originKind := GetKind(val)
 	tempVal := GetElem(val)
@@ -42,15 +49,21 @@ func extractBool(val reflect.Value, option *Option) (result bool, err error) {
 		}
 		str := getDefaultString(val)
 		result, err = strconv.ParseBool(str)
+		err = getErrParse(val, TypeBool, err)
 	}
 	return
 }
 
 func extractInt(val reflect.Value, option *Option) (result int64, err error) {
+	val = exposeValueOpt(val, option)
 	err = checkExtractValid(val, option)
 	if err != nil {
 		return
 	}
+	err = checkStrict(val, TypeInt64, option)
+	if err != nil {
+		return
+	}
 
 	originKind := GetKind(val)
 	tempVal := GetElem(val)
@@ -75,6 +88,15 @@ func extractInt(val reflect.Value, option *Option) (result int64, err error) {
 		result = val.Int()
 	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
 		result = int64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		result, err = roundFloatToInt(val, option)
+	case reflect.Struct:
+		if option.TimeToUnix && GetType(val) == TypeTime {
+			result, err = formatUnixTime(val.Interface().(time.Time), option)
+			return
+		}
+
+		fallthrough
 	default:
 		if val.CanAddr() && !IsKindPtr(originKind) {
 			result, err = castInt64(val.Addr().Interface())
@@ -92,15 +114,25 @@ func extractInt(val reflect.Value, option *Option) (result int64, err error) {
 			return
 		}
 		result, err = strconv.ParseInt(str, option.BaseSystem, option.BitSize)
+		if dec, ok := getNumericDecimal(str, err, option); ok {
+			result, err = roundDecimalToInt(val, dec, option)
+			return
+		}
+		err = getErrParse(val, TypeInt64, err)
 	}
 	return
 }
 
 func extractUint(val reflect.Value, option *Option) (result uint64, err error) {
+	val = exposeValueOpt(val, option)
 	err = checkExtractValid(val, option)
 	if err != nil {
 		return
 	}
+	err = checkStrict(val, TypeUint64, option)
+	if err != nil {
+		return
+	}
 
 	originKind := GetKind(val)
 	tempVal := GetElem(val)
@@ -122,14 +154,11 @@ func extractUint(val reflect.Value, option *Option) (result uint64, err error) {
 			result = 0
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		valInt := val.Int()
-		if valInt < 0 {
-			err = getErrOverflow(val)
-			return
-		}
-		result = uint64(valInt)
+		result, err = roundDecimalToUint(val, decimal.NewFromInt(val.Int()), option)
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		result = val.Uint()
+	case reflect.Float32, reflect.Float64:
+		result, err = roundFloatToUint(val, option)
 	default:
 		if val.CanAddr() && !IsKindPtr(originKind) {
 			result, err = castUint64(val.Addr().Interface())
@@ -147,15 +176,25 @@ func extractUint(val reflect.Value, option *Option) (result uint64, err error) {
 			return
 		}
 		result, err = strconv.ParseUint(str, option.BaseSystem, option.BitSize)
+		if dec, ok := getNumericDecimal(str, err, option); ok {
+			result, err = roundDecimalToUint(val, dec, option)
+			return
+		}
+		err = getErrParse(val, TypeUint64, err)
 	}
 	return
 }
 
 func extractFloat(val reflect.Value, option *Option) (result float64, err error) {
+	val = exposeValueOpt(val, option)
 	err = checkExtractValid(val, option)
 	if err != nil {
 		return
 	}
+	err = checkStrict(val, TypeFloat64, option)
+	if err != nil {
+		return
+	}
 
 	originKind := GetKind(val)
 	tempVal := GetElem(val)
@@ -176,9 +215,10 @@ func extractFloat(val reflect.Value, option *Option) (result float64, err error)
 		} else {
 			result = 0
 		}
-	case reflect.Int8, reflect.Int16, reflect.Int32:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// The integer is converted directly because its named type like time.Duration is formatted differently as string.
 		result = float64(val.Int())
-	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		result = float64(val.Uint())
 	case reflect.Float32, reflect.Float64:
 		result = val.Float()
@@ -199,15 +239,21 @@ func extractFloat(val reflect.Value, option *Option) (result float64, err error)
 			return
 		}
 		result, err = strconv.ParseFloat(str, option.BitSize)
+		err = getErrParse(val, TypeFloat64, err)
 	}
 	return
 }
 
 func extractComplex(val reflect.Value, option *Option) (result complex128, err error) {
+	val = exposeValueOpt(val, option)
 	err = checkExtractValid(val, option)
 	if err != nil {
 		return
 	}
+	err = checkStrict(val, TypeComplex128, option)
+	if err != nil {
+		return
+	}
 
 	originKind := GetKind(val)
 	tempVal := GetElem(val)
@@ -222,9 +268,10 @@ func extractComplex(val reflect.Value, option *Option) (result complex128, err e
 	err = nil
 
 	switch GetKind(val) {
-	case reflect.Int8, reflect.Int16:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// The integer is converted directly because its named type like time.Duration is formatted differently as string.
 		result = complex(float64(val.Int()), 0)
-	case reflect.Uint8, reflect.Uint16:
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		result = complex(float64(val.Uint()), 0)
 	case reflect.Float32, reflect.Float64:
 		result = complex(float64(val.Float()), 0)
@@ -247,16 +294,24 @@ func extractComplex(val reflect.Value, option *Option) (result complex128, err e
 			return
 		}
 		result, err = strconv.ParseComplex(str, option.ComplexBitSize)
+		err = getErrParse(val, TypeComplex128, err)
 	}
 	return
 }
 
 func extractString(val reflect.Value, option *Option) (result string, err error) {
+	val = exposeValueOpt(val, option)
 	err = checkExtractValid(val, option)
 	if err != nil {
 		return
 	}
 
+	var ok bool
+	result, ok = formatTypedString(val, option)
+	if ok {
+		return
+	}
+
 	originKind := GetKind(val)
 	tempVal := GetElem(val)
 	for IsValueElemable(val) && tempVal != val {
@@ -296,6 +351,7 @@ func extractString(val reflect.Value, option *Option) (result string, err error)
 }
 
 func extractTime(val reflect.Value, option *Option) (result time.Time, err error) {
+	val = exposeValueOpt(val, option)
 	val = GetChildElem(val)
 	err = checkExtractValid(val, option)
 	if err != nil {
@@ -303,13 +359,16 @@ func extractTime(val reflect.Value, option *Option) (result time.Time, err error
 	}
 
 	var timeStr string
-	switch GetKind(val) {
-	case reflect.String:
+	switch kind := GetKind(val); {
+	case kind == reflect.String:
 		timeStr = val.String()
-	case reflect.Struct:
+	case IsKindInt(kind) || IsKindUint(kind) || IsKindFloat(kind):
+		result, err = parseUnixTime(val, option)
+		return
+	case kind == reflect.Struct:
 		if GetType(val) == TypeTime {
 			timeVal := val.Interface().(time.Time)
-			result = timeVal
+			result = normalizeTime(timeVal, option)
 			return
 		}
 
@@ -322,25 +381,38 @@ func extractTime(val reflect.Value, option *Option) (result time.Time, err error
 	}
 
 	result, err = parseTime(timeStr, option)
+	if err != nil && isNumericString(timeStr) {
+		result, err = parseUnixTime(val, option.resetCheck())
+		return
+	}
+	err = getErrParse(val, TypeTime, err)
 	return
 }
 
 func extractDuration(val reflect.Value, option *Option) (res time.Duration, err error) {
+	val = exposeValueOpt(val, option)
 	val = GetChildElem(val)
 	err = checkExtractValid(val, option)
 	if err != nil {
 		return
 	}
 
-	switch GetKind(val) {
-	case reflect.String:
-		res, err = time.ParseDuration(val.String())
+	switch kind := GetKind(val); {
+	case kind == reflect.String:
+		res, err = parseDuration(val.String(), option)
+		if _, ok := err.(*OverflowError); !ok {
+			err = getErrParse(val, TypeDuration, err)
+		}
 	default:
 		if GetType(val) == TypeDuration {
 			durVal := val.Interface().(time.Duration)
 			res = durVal
 			return
 		}
+		if option.DurationUnit > 0 && (IsKindInt(kind) || IsKindUint(kind) || IsKindFloat(kind)) {
+			res, err = convertDuration(val, option)
+			return
+		}
 
 		var resInt64 int64
 		resInt64, err = extractInt(val, option)
@@ -353,7 +425,47 @@ func extractDuration(val reflect.Value, option *Option) (res time.Duration, err
 	return
 }
 
+func extractLocation(val reflect.Value, option *Option) (res *time.Location, err error) {
+	val = exposeValueOpt(val, option)
+	if GetType(val) == TypeLocationPtr && !val.IsNil() {
+		res = val.Interface().(*time.Location)
+		return
+	}
+
+	val = GetChildElem(val)
+	err = checkExtractValid(val, option)
+	if err != nil {
+		return
+	}
+
+	switch GetType(val) {
+	case TypeLocation:
+		// The address keeps the identity of the location, e.g. time.UTC.
+		if val.CanAddr() {
+			res = val.Addr().Interface().(*time.Location)
+			return
+		}
+		locVal := val.Interface().(time.Location)
+		res = &locVal
+		return
+	case TypeTime:
+		res = val.Interface().(time.Time).Location()
+		return
+	}
+
+	var name string
+	name, err = extractString(val, option)
+	if err != nil {
+		return
+	}
+
+	res, err = loadLocation(name)
+	err = getErrParse(val, TypeLocation, err)
+	return
+}
+
 func extractURL(val reflect.Value, option *Option) (res *url.URL, err error) {
+	val = exposeValueOpt(val, option)
 	val = GetChildElem(val)
 	err = checkExtractValid(val, option)
 	if err != nil {
@@ -363,6 +475,7 @@ func extractURL(val reflect.Value, option *Option) (res *url.URL, err error) {
 	switch GetKind(val) {
 	case reflect.String:
 		res, err = url.Parse(val.String())
+		err = getErrParse(val, TypeURL, err)
 	default:
 		if GetType(val) == TypeURL {
 			urlVal := val.Interface().(url.URL)
@@ -377,11 +490,13 @@ func extractURL(val reflect.Value, option *Option) (res *url.URL, err error) {
 		}
 
 		res, err = url.Parse(resURL)
+		err = getErrParse(val, TypeURL, err)
 	}
 	return
 }
 
 func extractIP(val reflect.Value, option *Option) (res net.IP, err error) {
+	val = exposeValueOpt(val, option)
 	val = GetChildElem(val)
 	err = checkExtractValid(val, option)
 	if err != nil {
@@ -410,7 +525,7 @@ func extractIP(val reflect.Value, option *Option) (res net.IP, err error) {
 }
 
 func tryExtract(val reflect.Value, opt *Option) (result interface{}, err error) {
-	val = GetChildElem(val)
+	val = GetChildElem(exposeValueOpt(val, opt))
 	err = checkExtractValid(val, opt)
 	if err != nil {
 		return
@@ -440,6 +555,8 @@ func tryExtract(val reflect.Value, opt *Option) (result interface{}, err error)
 			result, err = extractTime(val, opt)
 		case IsTypeValueURL(val):
 			result, err = extractURL(val, opt)
+		case IsTypeValueLocation(val):
+			result, err = extractLocation(val, opt)
 		default:
 			err = getErrUnimplementedExtract(val)
 		}
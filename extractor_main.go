@@ -95,6 +95,8 @@ func GetString(input interface{}, fnOpts ...FuncOption) (result string) {
 }
 
 // ExtractString gets the underlying string value from val of reflect.Value.
+// The time.Duration, time.Time, url.URL, net.IP, and []byte are formatted so that they can be parsed back,
+// the time.Time layout is set by WithTimeOutputLayout and the []byte encoding is set by WithBytesEncoding.
 func ExtractString(val reflect.Value, fnOpts ...FuncOption) (result string, err error) {
 	opt := NewOption().Assign(fnOpts...)
 	result, err = extractString(val, opt)
@@ -109,6 +111,7 @@ func GetTime(input interface{}, fnOpts ...FuncOption) (result time.Time) {
 }
 
 // ExtractTime extracts time.Time from val of reflect.Value.
+// The number and the numeric string are extracted as the Unix timestamp in the unit set by WithUnixUnit.
 func ExtractTime(val reflect.Value, fnOpts ...FuncOption) (result time.Time, err error) {
 	opt := NewOption().Assign(fnOpts...)
 	result, err = extractTime(val, opt)
@@ -123,12 +126,29 @@ func GetDuration(input interface{}, fnOpts ...FuncOption) (result time.Duration)
 }
 
 // ExtractDuration extracts time.Duration from val of reflect.Value.
+// The number is in the unit set by WithDurationUnit, and the string is parsed like ParseDuration.
 func ExtractDuration(val reflect.Value, fnOpts ...FuncOption) (result time.Duration, err error) {
 	opt := NewOption().Assign(fnOpts...)
 	result, err = extractDuration(val, opt)
 	return
 }
 
+// GetLocation accepts input as interface{}.
+// GetLocation is ExtractLocation without error.
+func GetLocation(input interface{}, fnOpts ...FuncOption) (result *time.Location) {
+	result, _ = ExtractLocation(getValFromInterface(input), fnOpts...)
+	return
+}
+
+// ExtractLocation extracts *time.Location from val of reflect.Value.
+// The string is loaded as the IANA time zone name, e.g. Asia/Jakarta,
+// and the time.Time returns its location.
+func ExtractLocation(val reflect.Value, fnOpts ...FuncOption) (result *time.Location, err error) {
+	opt := NewOption().Assign(fnOpts...)
+	result, err = extractLocation(val, opt)
+	return
+}
+
 // GetURL accepts input as interface{}.
 // GetURL is ExtractURL without error.
 func GetURL(input interface{}, fnOpts ...FuncOption) (result *url.URL) {
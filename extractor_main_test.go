@@ -1,6 +1,7 @@
 package reflecthelper
 
 import (
+	"errors"
 	"net"
 	"net/url"
 	"reflect"
@@ -417,3 +418,54 @@ func TestGetIP(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLocation(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name       string
+		input      interface{}
+		wantResult *time.Location
+		wantErr    bool
+	}{
+		{
+			name:       "tz name",
+			input:      "Asia/Jakarta",
+			wantResult: jakarta,
+		},
+		{
+			name:       "UTC name",
+			input:      "UTC",
+			wantResult: time.UTC,
+		},
+		{
+			name:       "location pointer",
+			input:      time.UTC,
+			wantResult: time.UTC,
+		},
+		{
+			name:       "location of time",
+			input:      time.Date(2021, 1, 1, 0, 0, 0, 0, jakarta),
+			wantResult: jakarta,
+		},
+		{
+			name:    "unknown tz name",
+			input:   "Mars/Olympus",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResult, err := ExtractLocation(reflect.ValueOf(tt.input))
+			if tt.wantErr {
+				var parseErr *ParseError
+				assert.True(t, errors.As(err, &parseErr))
+				assert.Nil(t, GetLocation(tt.input))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantResult.String(), gotResult.String())
+		})
+	}
+}
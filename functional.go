@@ -0,0 +1,243 @@
+package reflecthelper
+
+import "reflect"
+
+type (
+	// FuncMapElem is a function type to map each element of array or slice to a new value.
+	// The returned value can be an interface{} or a reflect.Value.
+	FuncMapElem func(index int, elem reflect.Value) (res interface{}, err error)
+
+	// FuncFilterElem is a function type to decide whether an element of array or slice is kept.
+	FuncFilterElem func(index int, elem reflect.Value) (keep bool, err error)
+
+	// FuncReduceElem is a function type to accumulate each element of array or slice into the acc.
+	// The returned value can be an interface{} or a reflect.Value.
+	FuncReduceElem func(acc reflect.Value, index int, elem reflect.Value) (res interface{}, err error)
+)
+
+func castList(input interface{}, fnOpts []FuncOption) (res Value, err error) {
+	res = Cast(getValFromInterface(input), fnOpts...)
+	if !res.isArrayOrSlice() {
+		err = getErrNotList(res.Value)
+	}
+	return
+}
+
+// MapSlice maps each element of the input array or slice using the fn and returns a new slice.
+// Each mapped value is converted to the targetElemType using AssignReflect.
+// If targetElemType is nil, the element type of the input is used.
+// The elements are mapped concurrently in the concurrent mode, but the output order is kept.
+func MapSlice(input interface{}, fn FuncMapElem, targetElemType reflect.Type, fnOpts ...FuncOption) (res reflect.Value, err error) {
+	list, err := castList(input, fnOpts)
+	if err != nil {
+		return
+	}
+	if targetElemType == nil {
+		targetElemType = list.Type().Elem()
+	}
+
+	output := reflect.MakeSlice(reflect.SliceOf(targetElemType), list.Len(), list.Len())
+	list.IterateArraySlice(func(arrSliceInput reflect.Value, index int, elem reflect.Value) (err error) {
+		mapped := elem
+		if fn != nil {
+			var mappedInterface interface{}
+			mappedInterface, err = fn(index, elem)
+			if err != nil {
+				return
+			}
+			mapped = getValFromInterface(mappedInterface)
+		}
+		if !mapped.IsValid() {
+			return
+		}
+		err = assignReflect(output.Index(index), mapped, list.opt.Clone())
+		return
+	})
+	err = list.Error()
+	if err != nil {
+		return
+	}
+
+	res = output
+	return
+}
+
+// FilterSlice returns a new slice containing the elements of the input array or slice kept by the fn.
+// The elements are checked concurrently in the concurrent mode, but the output order is kept.
+func FilterSlice(input interface{}, fn FuncFilterElem, fnOpts ...FuncOption) (res reflect.Value, err error) {
+	list, err := castList(input, fnOpts)
+	if err != nil {
+		return
+	}
+
+	keeps := make([]bool, list.Len())
+	list.IterateArraySlice(func(arrSliceInput reflect.Value, index int, elem reflect.Value) (err error) {
+		if fn == nil {
+			keeps[index] = true
+			return
+		}
+		keeps[index], err = fn(index, elem)
+		return
+	})
+	err = list.Error()
+	if err != nil {
+		return
+	}
+
+	res = reflect.MakeSlice(reflect.SliceOf(list.Type().Elem()), 0, list.Len())
+	for index, keep := range keeps {
+		if keep {
+			res = reflect.Append(res, list.Index(index))
+		}
+	}
+	return
+}
+
+// ReduceSlice accumulates each element of the input array or slice using the fn, starting from the initial.
+// The elements are always reduced sequentially, even in the concurrent mode.
+func ReduceSlice(input interface{}, fn FuncReduceElem, initial interface{}, fnOpts ...FuncOption) (res reflect.Value, err error) {
+	fnOpts = append(fnOpts[:len(fnOpts):len(fnOpts)], WithConcurrency(false))
+	list, err := castList(input, fnOpts)
+	if err != nil {
+		return
+	}
+
+	acc := getValFromInterface(initial)
+	list.IterateArraySlice(func(arrSliceInput reflect.Value, index int, elem reflect.Value) (err error) {
+		if fn == nil {
+			return
+		}
+		var result interface{}
+		result, err = fn(acc, index, elem)
+		if err != nil {
+			return
+		}
+		acc = getValFromInterface(result)
+		return
+	})
+	err = list.Error()
+	if err != nil {
+		return
+	}
+
+	res = acc
+	return
+}
+
+func pluckField(input interface{}, path string, fnOpts []FuncOption) (list Value, res reflect.Value, err error) {
+	list, err = castList(input, fnOpts)
+	if err != nil {
+		return
+	}
+	segments, err := ParsePath(path)
+	if err != nil {
+		return
+	}
+	fieldType, ok := getPathType(list.Type().Elem(), segments)
+	if !ok {
+		err = getErrPathNotFound(segments)
+		return
+	}
+
+	output := reflect.MakeSlice(reflect.SliceOf(fieldType), list.Len(), list.Len())
+	list.IterateArraySlice(func(arrSliceInput reflect.Value, index int, elem reflect.Value) (err error) {
+		field, err := lookupPath(elem, segments, list.opt.Clone())
+		if err != nil {
+			return
+		}
+		err = getErrCanInterface(field)
+		if err != nil {
+			return
+		}
+		output.Index(index).Set(field)
+		return
+	})
+	err = list.Error()
+	if err != nil {
+		return
+	}
+
+	res = output
+	return
+}
+
+func checkMapKeyType(typ reflect.Type) (err error) {
+	if !typ.Comparable() {
+		err = getErrUncomparableKey(typ)
+	}
+	return
+}
+
+func checkMapKey(key reflect.Value) (err error) {
+	if IsKindInterface(GetKind(key)) && !key.IsNil() {
+		key = key.Elem()
+	}
+	err = checkMapKeyType(key.Type())
+	return
+}
+
+// PluckField returns a new slice containing the value located at the path of each element of the input array or slice.
+// The path is resolved using LookupPath, e.g. Address.City.
+func PluckField(input interface{}, path string, fnOpts ...FuncOption) (res reflect.Value, err error) {
+	_, res, err = pluckField(input, path, fnOpts)
+	return
+}
+
+// GroupByField groups the elements of the input array or slice by the value located at the path of each element.
+// It returns a map with the field value as the key and a slice of the grouped elements as the value.
+// The grouped elements keep the order of the input.
+func GroupByField(input interface{}, path string, fnOpts ...FuncOption) (res reflect.Value, err error) {
+	list, keys, err := pluckField(input, path, fnOpts)
+	if err != nil {
+		return
+	}
+	err = checkMapKeyType(keys.Type().Elem())
+	if err != nil {
+		return
+	}
+
+	sliceType := reflect.SliceOf(list.Type().Elem())
+	output := reflect.MakeMap(reflect.MapOf(keys.Type().Elem(), sliceType))
+	for index := 0; index < keys.Len(); index++ {
+		key := keys.Index(index)
+		err = checkMapKey(key)
+		if err != nil {
+			return
+		}
+		group := output.MapIndex(key)
+		if !group.IsValid() {
+			group = reflect.MakeSlice(sliceType, 0, 1)
+		}
+		output.SetMapIndex(key, reflect.Append(group, list.Index(index)))
+	}
+
+	res = output
+	return
+}
+
+// IndexByField indexes the elements of the input array or slice by the value located at the path of each element.
+// It returns a map with the field value as the key and the element as the value.
+// The last element wins if there are duplicate keys.
+func IndexByField(input interface{}, path string, fnOpts ...FuncOption) (res reflect.Value, err error) {
+	list, keys, err := pluckField(input, path, fnOpts)
+	if err != nil {
+		return
+	}
+	err = checkMapKeyType(keys.Type().Elem())
+	if err != nil {
+		return
+	}
+
+	output := reflect.MakeMap(reflect.MapOf(keys.Type().Elem(), list.Type().Elem()))
+	for index := 0; index < keys.Len(); index++ {
+		key := keys.Index(index)
+		err = checkMapKey(key)
+		if err != nil {
+			return
+		}
+		output.SetMapIndex(key, list.Index(index))
+	}
+
+	res = output
+	return
+}
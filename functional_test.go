@@ -0,0 +1,178 @@
+package reflecthelper
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type funcEmployee struct {
+	Name   string
+	Dept   string
+	Salary int
+	Skills []string
+	Boss   *funcEmployee
+}
+
+func getFuncEmployees() []funcEmployee {
+	return []funcEmployee{
+		{Name: "alice", Dept: "eng", Salary: 10},
+		{Name: "bob", Dept: "ops", Salary: 7},
+		{Name: "carol", Dept: "eng", Salary: 12},
+	}
+}
+
+func TestMapSlice(t *testing.T) {
+	for _, concurrent := range []bool{false, true} {
+		res, err := MapSlice(getFuncEmployees(), func(index int, elem reflect.Value) (interface{}, error) {
+			return elem.FieldByName("Salary").Int() * 2, nil
+		}, reflect.TypeOf(""), WithConcurrency(concurrent))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"20", "14", "24"}, res.Interface())
+	}
+
+	t.Run("nil fn and nil target type", func(t *testing.T) {
+		res, err := MapSlice([2]int{1, 2}, nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2}, res.Interface())
+	})
+	t.Run("reflect.Value and nil result", func(t *testing.T) {
+		res, err := MapSlice([]int{1, 2}, func(index int, elem reflect.Value) (interface{}, error) {
+			if index == 0 {
+				return nil, nil
+			}
+			return elem, nil
+		}, reflect.TypeOf(float64(0)))
+		assert.Nil(t, err)
+		assert.Equal(t, []float64{0, 2}, res.Interface())
+	})
+	t.Run("error from the fn and conversion", func(t *testing.T) {
+		_, err := MapSlice([]int{1}, func(index int, elem reflect.Value) (interface{}, error) {
+			return nil, ErrTesting
+		}, nil)
+		assert.True(t, errors.Is(err, ErrTesting))
+
+		_, err = MapSlice([]string{"a"}, nil, reflect.TypeOf(0))
+		assert.NotNil(t, err)
+	})
+	t.Run("not a list", func(t *testing.T) {
+		_, err := MapSlice(1, nil, nil)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestFilterSlice(t *testing.T) {
+	for _, concurrent := range []bool{false, true} {
+		res, err := FilterSlice(getFuncEmployees(), func(index int, elem reflect.Value) (bool, error) {
+			return elem.FieldByName("Dept").String() == "eng", nil
+		}, WithConcurrency(concurrent))
+		assert.Nil(t, err)
+		employees := res.Interface().([]funcEmployee)
+		assert.Len(t, employees, 2)
+		assert.Equal(t, "alice", employees[0].Name)
+		assert.Equal(t, "carol", employees[1].Name)
+	}
+
+	res, err := FilterSlice(&[]int{1, 2}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, res.Interface())
+
+	_, err = FilterSlice([]int{1}, func(index int, elem reflect.Value) (bool, error) {
+		return false, ErrTesting
+	})
+	assert.NotNil(t, err)
+	_, err = FilterSlice(nil, nil)
+	assert.NotNil(t, err)
+}
+
+func TestReduceSlice(t *testing.T) {
+	res, err := ReduceSlice(getFuncEmployees(), func(acc reflect.Value, index int, elem reflect.Value) (interface{}, error) {
+		return acc.Int() + elem.FieldByName("Salary").Int(), nil
+	}, int64(0), WithConcurrency(true))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(29), res.Interface())
+
+	res, err = ReduceSlice([]string{"a", "b"}, func(acc reflect.Value, index int, elem reflect.Value) (interface{}, error) {
+		return reflect.ValueOf(acc.String() + strings.ToUpper(elem.String())), nil
+	}, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "AB", res.Interface())
+
+	res, err = ReduceSlice([]int{1}, nil, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, res.Interface())
+
+	_, err = ReduceSlice([]int{1}, func(acc reflect.Value, index int, elem reflect.Value) (interface{}, error) {
+		return nil, ErrTesting
+	}, 0)
+	assert.NotNil(t, err)
+	_, err = ReduceSlice("abc", nil, 0)
+	assert.NotNil(t, err)
+}
+
+func TestPluckField(t *testing.T) {
+	employees := getFuncEmployees()
+	employees[1].Boss = &employees[0]
+
+	res, err := PluckField(employees, "Name", WithConcurrency(true))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, res.Interface())
+
+	res, err = PluckField(employees[1:2], "Boss.Name")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice"}, res.Interface())
+
+	_, err = PluckField(employees, "Boss.Name")
+	assert.NotNil(t, err)
+	_, err = PluckField(employees, "Unknown")
+	assert.NotNil(t, err)
+	_, err = PluckField(employees, "Name[")
+	assert.NotNil(t, err)
+	_, err = PluckField(1, "Name")
+	assert.NotNil(t, err)
+
+	type secret struct {
+		value string
+	}
+	_, err = PluckField([]secret{{"a"}}, "value")
+	assert.NotNil(t, err)
+}
+
+func TestGroupByField(t *testing.T) {
+	res, err := GroupByField(getFuncEmployees(), "Dept", WithConcurrency(true))
+	assert.Nil(t, err)
+	groups := res.Interface().(map[string][]funcEmployee)
+	assert.Len(t, groups, 2)
+	assert.Equal(t, "alice", groups["eng"][0].Name)
+	assert.Equal(t, "carol", groups["eng"][1].Name)
+	assert.Equal(t, "bob", groups["ops"][0].Name)
+
+	res, err = GroupByField([]interface{}{map[string]interface{}{"k": 1}, map[string]interface{}{"k": 1}}, "k")
+	assert.Nil(t, err)
+	assert.Len(t, res.Interface().(map[interface{}][]interface{})[1], 2)
+
+	_, err = GroupByField([]interface{}{map[string]interface{}{"k": []int{1}}}, "k")
+	assert.NotNil(t, err)
+	_, err = GroupByField(getFuncEmployees(), "Skills")
+	assert.NotNil(t, err)
+	_, err = GroupByField(getFuncEmployees(), "Unknown")
+	assert.NotNil(t, err)
+}
+
+func TestIndexByField(t *testing.T) {
+	res, err := IndexByField(getFuncEmployees(), "Dept")
+	assert.Nil(t, err)
+	index := res.Interface().(map[string]funcEmployee)
+	assert.Equal(t, "carol", index["eng"].Name)
+	assert.Equal(t, "bob", index["ops"].Name)
+
+	_, err = IndexByField([]interface{}{map[string]interface{}{"k": []int{1}}}, "k")
+	assert.NotNil(t, err)
+	_, err = IndexByField(getFuncEmployees(), "Skills")
+	assert.NotNil(t, err)
+	_, err = IndexByField(getFuncEmployees(), "Unknown")
+	assert.NotNil(t, err)
+}
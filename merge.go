@@ -0,0 +1,250 @@
+package reflecthelper
+
+import (
+	"reflect"
+	"strings"
+)
+
+// TagMerge is the struct tag key read by Merge.
+const TagMerge = "merge"
+
+// MergeStrategy is the strategy of merging the src to the dst.
+// The strategies can be combined, e.g. MergeNonZero | MergeAppendSlice.
+type MergeStrategy uint8
+
+// List of all MergeStrategy used in this package.
+const (
+	// MergeOverwrite overwrites the dst with the src, including the zero src.
+	MergeOverwrite MergeStrategy = 0
+	// MergeNonZero only overwrites the dst if the src is not zero based on IsValueZero.
+	MergeNonZero MergeStrategy = 1 << iota
+	// MergeAppendSlice appends the src elements to the dst slice.
+	MergeAppendSlice
+	// MergeDedupeSlice appends the src elements that don't exist yet in the dst slice.
+	MergeDedupeSlice
+	// MergeDeepMap merges the src map entries recursively to the dst map entries.
+	MergeDeepMap
+)
+
+var mergeStrategyNames = map[string]MergeStrategy{
+	"overwrite": MergeOverwrite,
+	"nonzero":   MergeNonZero,
+	"append":    MergeAppendSlice,
+	"dedupe":    MergeDedupeSlice,
+	"deep":      MergeDeepMap,
+}
+
+// FuncMerger is a custom function to merge the src to the dst.
+// The dst is always settable and the src is already dereferenced from its pointers and interfaces.
+type FuncMerger func(dst reflect.Value, src reflect.Value) (err error)
+
+func (s MergeStrategy) has(strategy MergeStrategy) bool {
+	return s&strategy != 0
+}
+
+func parseMergeTag(tag string) (res MergeStrategy, err error) {
+	for _, name := range strings.Split(tag, ",") {
+		strategy, ok := mergeStrategyNames[strings.TrimSpace(name)]
+		if !ok {
+			err = getErrUnknownMergeStrategy(name)
+			return
+		}
+		res |= strategy
+	}
+	return
+}
+
+type merger struct {
+	opt       *Option
+	ancestors map[valueVisit]struct{}
+}
+
+func newMerger(opt *Option) *merger {
+	return &merger{
+		opt:       opt,
+		ancestors: make(map[valueVisit]struct{}),
+	}
+}
+
+func (m *merger) merge(dst reflect.Value, src reflect.Value, strategy MergeStrategy) (err error) {
+	visit, tracked := getValueVisit(src)
+	if tracked {
+		if _, ok := m.ancestors[visit]; ok {
+			return
+		}
+		m.ancestors[visit] = struct{}{}
+		defer delete(m.ancestors, visit)
+	}
+
+	err = m.mergeValue(dst, GetChildElem(src), strategy)
+	return
+}
+
+func (m *merger) mergeValue(dst reflect.Value, src reflect.Value, strategy MergeStrategy) (err error) {
+	if fn, ok := m.opt.TypeMergers[dst.Type()]; ok {
+		err = fn(dst, src)
+		return
+	}
+
+	srcNil := isCompareNil(src)
+	if strategy.has(MergeNonZero) && (srcNil || IsValueZero(src)) {
+		return
+	}
+	if srcNil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return
+	}
+
+	srcKind := GetKind(src)
+	switch GetKind(dst) {
+	case reflect.Ptr:
+		// The *time.Location is replaced instead of merged so that the shared location like time.UTC is not overwritten.
+		if dst.Type() == TypeLocationPtr {
+			break
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		err = m.mergeValue(dst.Elem(), src, strategy)
+		return
+	case reflect.Struct:
+		if !IsTypeValueTime(dst) && !IsTypeValueURL(dst) && (IsKindStruct(srcKind) || IsKindMap(srcKind)) {
+			err = m.mergeStruct(dst, src, strategy)
+			return
+		}
+	case reflect.Slice:
+		if strategy.has(MergeAppendSlice|MergeDedupeSlice) && IsKindList(srcKind) {
+			err = m.mergeSlice(dst, src, strategy)
+			return
+		}
+	case reflect.Map:
+		if strategy.has(MergeDeepMap) && IsKindMap(srcKind) && !dst.IsNil() {
+			err = m.mergeMap(dst, src, strategy)
+			return
+		}
+	}
+
+	err = assignReflect(dst, src, m.opt)
+	return
+}
+
+// mergeStruct merges the src struct fields or map entries to the dst struct fields having the same name.
+func (m *merger) mergeStruct(dst reflect.Value, src reflect.Value, strategy MergeStrategy) (err error) {
+	typ := dst.Type()
+	for index := 0; index < dst.NumField(); index++ {
+		field := typ.Field(index)
+		tag := field.Tag.Get(TagMerge)
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+
+		var srcField reflect.Value
+		srcField, err = lookupPathSegment(src, PathSegment{Kind: PathField, Name: field.Name}, m.opt)
+		if err != nil {
+			return
+		}
+		if !srcField.IsValid() && IsKindMap(GetKind(src)) {
+			srcField = getMapValueFold(src, field.Name, m.opt)
+		}
+		if !srcField.IsValid() {
+			continue
+		}
+
+		fieldStrategy := strategy
+		if tag != "" {
+			fieldStrategy, err = parseMergeTag(tag)
+			if err != nil {
+				err = getErrMergeField(field.Name, err)
+				return
+			}
+		}
+		err = m.merge(dst.Field(index), srcField, fieldStrategy)
+		if err != nil {
+			err = getErrMergeField(field.Name, setErrorPath(err, field.Name))
+			return
+		}
+	}
+	return
+}
+
+// getMapValueFold gets the map value having the string key equal to the name under case-folding.
+func getMapValueFold(val reflect.Value, name string, opt *Option) (res reflect.Value) {
+	if val.Type().Key().Kind() != reflect.String {
+		return
+	}
+
+	for _, entry := range getMapEntries(val, opt) {
+		if strings.EqualFold(entry.key.String(), name) {
+			res = entry.value
+			return
+		}
+	}
+	return
+}
+
+func (m *merger) mergeSlice(dst reflect.Value, src reflect.Value, strategy MergeStrategy) (err error) {
+	res := reflect.MakeSlice(dst.Type(), dst.Len(), dst.Len()+src.Len())
+	reflect.Copy(res, dst)
+	for index := 0; index < src.Len(); index++ {
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		err = assignReflect(elem, src.Index(index), m.opt)
+		if err != nil {
+			return
+		}
+		if strategy.has(MergeDedupeSlice) && containsValue(res, elem) {
+			continue
+		}
+		res = reflect.Append(res, elem)
+	}
+	dst.Set(res)
+	return
+}
+
+func containsValue(list reflect.Value, elem reflect.Value) bool {
+	for index := 0; index < list.Len(); index++ {
+		if reflect.DeepEqual(list.Index(index).Interface(), elem.Interface()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *merger) mergeMap(dst reflect.Value, src reflect.Value, strategy MergeStrategy) (err error) {
+	typ := dst.Type()
+	res := reflect.MakeMapWithSize(typ, dst.Len())
+	iter := dst.MapRange()
+	for iter.Next() {
+		res.SetMapIndex(iter.Key(), iter.Value())
+	}
+
+	for _, entry := range getMapEntries(src, m.opt) {
+		key := reflect.New(typ.Key()).Elem()
+		err = assignReflect(key, entry.key, m.opt)
+		if err != nil {
+			return
+		}
+
+		elem := reflect.New(typ.Elem()).Elem()
+		if existing := res.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+			if GetKind(elem) == reflect.Interface && !elem.IsNil() {
+				inner := reflect.New(elem.Elem().Type()).Elem()
+				inner.Set(elem.Elem())
+				err = m.merge(inner, entry.value, strategy)
+				if err != nil {
+					return
+				}
+				elem.Set(inner)
+				res.SetMapIndex(key, elem)
+				continue
+			}
+		}
+		err = m.merge(elem, entry.value, strategy)
+		if err != nil {
+			return
+		}
+		res.SetMapIndex(key, elem)
+	}
+	dst.Set(res)
+	return
+}
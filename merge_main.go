@@ -0,0 +1,33 @@
+package reflecthelper
+
+// Merge merges the src to the dst recursively, e.g. for layering the configurations from file, env, and flags.
+// The dst must be a pointer or a settable reflect.Value, the src can be an interface{} or a reflect.Value.
+//
+// The struct fields are merged with the src struct fields or map entries having the same name.
+// The nil dst pointers are initialized when the src is merged into them.
+// The src values having different types with the dst are converted using the assignment of this package.
+// The strategy is set globally using WithMergeStrategy and can be overridden per field using the merge struct tag,
+// e.g. `merge:"nonzero,append"`. The names of the strategies in the tag are overwrite, nonzero, append, dedupe, and deep.
+// The custom merger for a specific type can be set using WithTypeMerger.
+// Unexported fields and fields tagged with `merge:"-"` are skipped.
+func Merge(dst interface{}, src interface{}, fnOpts ...FuncOption) (err error) {
+	opt := NewOption().Assign(fnOpts...)
+	defer recoverFnOpt(&err, opt)
+
+	dstVal := getValFromInterface(dst)
+	if IsValueElemable(dstVal) && !dstVal.IsNil() {
+		dstVal = dstVal.Elem()
+	}
+	err = checkAssigner(dstVal)
+	if err != nil {
+		return
+	}
+
+	srcVal := getValFromInterface(src)
+	if !srcVal.IsValid() {
+		return
+	}
+
+	err = newMerger(opt).merge(dstVal, srcVal, opt.MergeStrategy)
+	return
+}
@@ -0,0 +1,174 @@
+package reflecthelper
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mergeServer struct {
+	Host string
+	Port int
+}
+
+type mergeConfig struct {
+	Name     string
+	Debug    bool
+	Timeout  time.Duration
+	Tags     []string `merge:"dedupe"`
+	Plugins  []string `merge:"append"`
+	Labels   map[string]interface{}
+	Server   *mergeServer
+	Backup   mergeServer
+	Readonly string `merge:"-"`
+	Strict   string `merge:"overwrite"`
+}
+
+type mergeFlags struct {
+	Name    *string
+	Timeout string
+	Server  map[string]interface{}
+}
+
+func getMergeConfig() mergeConfig {
+	return mergeConfig{
+		Name:     "base",
+		Debug:    true,
+		Timeout:  time.Second,
+		Tags:     []string{"a", "b"},
+		Plugins:  []string{"x"},
+		Labels:   map[string]interface{}{"env": "dev", "nested": map[string]interface{}{"a": 1}},
+		Backup:   mergeServer{Host: "backup", Port: 1},
+		Readonly: "readonly",
+		Strict:   "strict",
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Run("overwrite", func(t *testing.T) {
+		dst := getMergeConfig()
+		err := Merge(&dst, mergeConfig{Name: "file", Tags: []string{"b", "c"}, Server: &mergeServer{Host: "h"}})
+		assert.Nil(t, err)
+		assert.Equal(t, "file", dst.Name)
+		assert.False(t, dst.Debug)
+		assert.Zero(t, dst.Timeout)
+		assert.Equal(t, []string{"a", "b", "c"}, dst.Tags)
+		assert.Equal(t, []string{"x"}, dst.Plugins)
+		assert.Nil(t, dst.Labels)
+		assert.Equal(t, &mergeServer{Host: "h"}, dst.Server)
+		assert.Equal(t, mergeServer{}, dst.Backup)
+		assert.Equal(t, "readonly", dst.Readonly)
+		assert.Empty(t, dst.Strict)
+	})
+
+	t.Run("non zero with deep maps", func(t *testing.T) {
+		dst := getMergeConfig()
+		labels := dst.Labels
+		err := Merge(&dst, mergeConfig{
+			Name:    "env",
+			Plugins: []string{"y", "x"},
+			Labels:  map[string]interface{}{"region": "id", "nested": map[string]interface{}{"b": 2}},
+			Backup:  mergeServer{Port: 2},
+		}, WithMergeStrategy(MergeNonZero|MergeDeepMap))
+		assert.Nil(t, err)
+		assert.Equal(t, "env", dst.Name)
+		assert.True(t, dst.Debug)
+		assert.Equal(t, time.Second, dst.Timeout)
+		assert.Equal(t, []string{"a", "b"}, dst.Tags)
+		assert.Equal(t, []string{"x", "y", "x"}, dst.Plugins)
+		assert.Equal(t, map[string]interface{}{
+			"env":    "dev",
+			"region": "id",
+			"nested": map[string]interface{}{"a": 1, "b": 2},
+		}, dst.Labels)
+		assert.Equal(t, map[string]interface{}{"env": "dev", "nested": map[string]interface{}{"a": 1}}, labels)
+		assert.Equal(t, mergeServer{Host: "backup", Port: 2}, dst.Backup)
+		assert.Empty(t, dst.Strict)
+	})
+
+	t.Run("compatible types and map source", func(t *testing.T) {
+		dst := getMergeConfig()
+		name := "flag"
+		err := Merge(&dst, mergeFlags{
+			Name:    &name,
+			Timeout: "1m",
+			Server:  map[string]interface{}{"host": "localhost", "port": "8080"},
+		}, WithMergeStrategy(MergeNonZero))
+		assert.Nil(t, err)
+		assert.Equal(t, "flag", dst.Name)
+		assert.Equal(t, time.Minute, dst.Timeout)
+		assert.Equal(t, &mergeServer{Host: "localhost", Port: 8080}, dst.Server)
+	})
+
+	t.Run("custom type merger", func(t *testing.T) {
+		dst := getMergeConfig()
+		err := Merge(&dst, mergeConfig{Name: "new"}, WithMergeStrategy(MergeNonZero), WithTypeMerger(reflect.TypeOf(""), func(dst reflect.Value, src reflect.Value) (err error) {
+			if src.String() != "" {
+				dst.SetString(dst.String() + "+" + src.String())
+			}
+			return
+		}))
+		assert.Nil(t, err)
+		assert.Equal(t, "base+new", dst.Name)
+		assert.Equal(t, "readonly", dst.Readonly)
+		assert.Equal(t, "strict", dst.Strict)
+
+		err = Merge(&dst, mergeConfig{Name: "x"}, WithTypeMerger(reflect.TypeOf(""), func(dst reflect.Value, src reflect.Value) error {
+			return ErrTesting
+		}))
+		assert.True(t, errors.Is(err, ErrTesting))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		type node struct {
+			Name string
+			Next *node
+		}
+		src := &node{Name: "src"}
+		src.Next = src
+		var dst node
+		assert.Nil(t, Merge(&dst, src))
+		assert.Equal(t, "src", dst.Name)
+		assert.Nil(t, dst.Next)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		dst := getMergeConfig()
+		assert.Equal(t, ErrAssignerCantSet, Merge(dst, mergeConfig{}))
+		assert.Nil(t, Merge(&dst, nil))
+		assert.Equal(t, "base", dst.Name)
+
+		err := Merge(&dst, map[string]interface{}{"Timeout": "abc"})
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "Timeout"))
+
+		type invalidTag struct {
+			Name string `merge:"unknown"`
+		}
+		err = Merge(&invalidTag{}, invalidTag{Name: "x"})
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "unknown"))
+	})
+}
+
+func TestMergeMapNaNKey(t *testing.T) {
+	dst := map[float64]int{math.NaN(): 1, 1: 1}
+	src := map[float64]int{math.NaN(): 2, 2: 2}
+	err := Merge(&dst, src, WithMergeStrategy(MergeDeepMap))
+	assert.Nil(t, err)
+	assert.Len(t, dst, 4)
+
+	var nanValues []int
+	for key, value := range dst {
+		if math.IsNaN(key) {
+			nanValues = append(nanValues, value)
+		}
+	}
+	assert.ElementsMatch(t, []int{1, 2}, nanValues)
+	assert.Equal(t, 2, dst[2])
+}
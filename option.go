@@ -2,6 +2,8 @@ package reflecthelper
 
 import (
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/Popog/deepcopy"
 	"github.com/mitchellh/mapstructure"
@@ -21,11 +23,43 @@ type Option struct {
 
 	// Not affected by Default() method
 	IgnoreError           bool
+	CollectErrors         bool
 	RecoverPanic          bool
 	BlockChannelIteration bool
 	ConcurrentMode        bool
+	MaxWorkers            int
+	FlattenEmbedded       bool
+	SkipUnexported        bool
+	UnexportedAccess      bool
+	TimeInference         bool
+	DayFirst              bool
+	Location              *time.Location
+	NormalizeTimeUTC      bool
+	UnixUnit              UnixUnit
+	TimeToUnix            bool
+	ExtendedDuration      bool
+	DurationUnit          time.Duration
+	DurationUnits         map[string]time.Duration
+	RelativeTime          bool
+	Clock                 FuncClock
+	TimeOutputLayout      string
+	BytesEncoding         BytesEncoding
+	StrictMode            StrictPolicy
+	RoundingMode          RoundingMode
+	OverflowPolicy        OverflowPolicy
+	FieldTagFilter        string
+	SortMapKeys           bool
+	MapKeyComparator      FuncComparator
+	NilOrder              NilOrder
+	NaturalStringOrder    bool
+	ChannelTimeout        time.Duration
 	FnAssigner            FuncAssigner
 	ContinueAssignOnError bool
+	CloneMaxDepth         int
+	CloneShallowTypes     []reflect.Type
+	CloneSkipPaths        []string
+	MergeStrategy         MergeStrategy
+	TypeMergers           map[reflect.Type]FuncMerger
 }
 
 // FuncAssigner is a custom function to assign from the val of reflect.Value to the assigner of reflect.Value.
@@ -66,6 +100,155 @@ func WithTimeLayouts(timeLayouts ...string) FuncOption {
 	}
 }
 
+// WithTimeInference toggles the inference of the time layout if the time layouts fail to parse the time string,
+// e.g. 2006-01-02, 2006-01-02 15:04:05, 01/02/2006, 20060102, and the ISO 8601 week dates like 2021-W05-3.
+// The default behavior for this package is false.
+func WithTimeInference(input bool) FuncOption {
+	return func(o *Option) {
+		o.TimeInference = input
+	}
+}
+
+// WithDayFirst toggles the day-first order for the ambiguous numeric dates inferred by WithTimeInference,
+// e.g. 01/02/2006 is parsed as 1 February 2006 instead of 2 January 2006.
+// The default behavior for this package is false.
+func WithDayFirst(input bool) FuncOption {
+	return func(o *Option) {
+		o.DayFirst = input
+	}
+}
+
+// WithLocation sets the location used to parse the time string without the zone information.
+// The time string with the zone offset is still parsed using its own offset.
+// The default behavior for this package is time.UTC.
+func WithLocation(loc *time.Location) FuncOption {
+	return func(o *Option) {
+		o.Location = loc
+	}
+}
+
+// WithNormalizeTimeUTC toggles the conversion of the extracted time.Time to UTC.
+// The default behavior for this package is false.
+func WithNormalizeTimeUTC(input bool) FuncOption {
+	return func(o *Option) {
+		o.NormalizeTimeUTC = input
+	}
+}
+
+// WithUnixUnit sets the unit of the Unix timestamp extracted to or from the time.Time.
+// The default behavior for this package is UnixAuto.
+func WithUnixUnit(unit UnixUnit) FuncOption {
+	return func(o *Option) {
+		o.UnixUnit = unit
+	}
+}
+
+// WithTimeToUnix toggles the extraction of the time.Time to the integer as the Unix timestamp,
+// e.g. assigning the time.Time to the int64 field. The unit is set by WithUnixUnit.
+// The default behavior for this package is false.
+func WithTimeToUnix(input bool) FuncOption {
+	return func(o *Option) {
+		o.TimeToUnix = input
+	}
+}
+
+// WithExtendedDuration toggles the extended duration parsing if time.ParseDuration fails,
+// e.g. 1d, 2w, 1.5 hours, 1 day and 2 hours, 01:30:00, and the ISO 8601 durations like P1DT2H30M.
+// The ISO 8601 year and month are approximated as 365 days and 30 days.
+// The default behavior for this package is false.
+func WithExtendedDuration(input bool) FuncOption {
+	return func(o *Option) {
+		o.ExtendedDuration = input
+	}
+}
+
+// WithDurationUnit sets the unit of the number extracted to the time.Duration, e.g. time.Second.
+// The default behavior for this package is time.Nanosecond.
+func WithDurationUnit(unit time.Duration) FuncOption {
+	return func(o *Option) {
+		o.DurationUnit = unit
+	}
+}
+
+// WithDurationUnits adds the custom unit names used by the extended duration parsing, e.g. fortnight.
+// The names are case-insensitive and override the built-in unit names.
+func WithDurationUnits(units map[string]time.Duration) FuncOption {
+	return func(o *Option) {
+		// The map is copied because the existing map may be shared with the cloned options.
+		res := make(map[string]time.Duration, len(o.DurationUnits)+len(units))
+		for name, unit := range o.DurationUnits {
+			res[name] = unit
+		}
+		for name, unit := range units {
+			res[strings.ToLower(name)] = unit
+		}
+		o.DurationUnits = res
+	}
+}
+
+// WithRelativeTime toggles the parsing of the relative time expressions,
+// e.g. now, now-15m, today, yesterday, tomorrow, +2h, start of week, and start of month.
+// The relative time is evaluated in the location set by WithLocation.
+// The default behavior for this package is false.
+func WithRelativeTime(input bool) FuncOption {
+	return func(o *Option) {
+		o.RelativeTime = input
+	}
+}
+
+// WithClock sets the clock used to evaluate the relative time expressions.
+// The default behavior for this package is time.Now.
+func WithClock(fn FuncClock) FuncOption {
+	return func(o *Option) {
+		o.Clock = fn
+	}
+}
+
+// WithTimeOutputLayout sets the layout used to format the time.Time to the string.
+// The layout is also tried first when parsing the string to the time.Time.
+// The default behavior for this package is time.RFC3339Nano.
+func WithTimeOutputLayout(layout string) FuncOption {
+	return func(o *Option) {
+		o.TimeOutputLayout = layout
+	}
+}
+
+// WithBytesEncoding sets the encoding used to convert the []byte from or to the string.
+// The default behavior for this package is BytesRaw.
+func WithBytesEncoding(encoding BytesEncoding) FuncOption {
+	return func(o *Option) {
+		o.BytesEncoding = encoding
+	}
+}
+
+// WithStrictMode sets the policy of the coercions disallowed in the extraction and the assignment,
+// e.g. WithStrictMode(StrictAll) or WithStrictMode(StrictStringToNumber|StrictBoolNumber).
+// The violation returns the *StrictError.
+// The default behavior for this package is StrictNone.
+func WithStrictMode(policy StrictPolicy) FuncOption {
+	return func(o *Option) {
+		o.StrictMode = policy
+	}
+}
+
+// WithRoundingMode sets the mode used to convert the fractional float or numeric string to the integer,
+// e.g. 2.7 is 2 using RoundTruncate and 3 using RoundHalfUp.
+// The default behavior for this package is RoundError.
+func WithRoundingMode(mode RoundingMode) FuncOption {
+	return func(o *Option) {
+		o.RoundingMode = mode
+	}
+}
+
+// WithOverflowPolicy sets the policy applied when the number overflows the destination type,
+// e.g. OverflowSaturate clamps 300 to 127 for the int8 field.
+// The default behavior for this package is OverflowReturnError.
+func WithOverflowPolicy(policy OverflowPolicy) FuncOption {
+	return func(o *Option) {
+		o.OverflowPolicy = policy
+	}
+}
+
 // WithIgnoreError toggles for ignoring error in the struct, slice, array, and map iteration.
 // The default behavior for this package is false.
 func WithIgnoreError(input bool) FuncOption {
@@ -74,6 +257,16 @@ func WithIgnoreError(input bool) FuncOption {
 	}
 }
 
+// WithCollectErrors toggles for collecting all errors in the struct, slice, array, map, and channel iteration.
+// The iteration continues on error and the collected errors are returned as MultiError of *ElemError.
+// This option has no effect if the IgnoreError is true.
+// The default behavior for this package is false.
+func WithCollectErrors(input bool) FuncOption {
+	return func(o *Option) {
+		o.CollectErrors = input
+	}
+}
+
 // WithPanicRecoverer toggles the panic recoverer in all of the packages' functions.
 // The default behavior for this package is false.
 func WithPanicRecoverer(input bool) FuncOption {
@@ -100,6 +293,124 @@ func WithConcurrency(input bool) FuncOption {
 	}
 }
 
+// WithMaxWorkers limits the number of elements processed at the same time in the concurrent mode.
+// The default behavior for this package is 0, that means there is no limit.
+func WithMaxWorkers(n int) FuncOption {
+	return func(o *Option) {
+		o.MaxWorkers = n
+	}
+}
+
+// WithChannelTimeout sets the maximum duration to wait for each receive in the blocking channel iteration.
+// The iteration stops with ErrChannelTimeout when no value is received within the timeout.
+// The default behavior for this package is 0, that means the receive waits until the channel is closed.
+func WithChannelTimeout(timeout time.Duration) FuncOption {
+	return func(o *Option) {
+		o.ChannelTimeout = timeout
+	}
+}
+
+// WithFlattenEmbedded toggles the flattening of embedded struct fields in IterateStructFields.
+// The promoted fields follow the Go promotion rules, the shallowest field wins and
+// the ambiguous fields at the same depth are skipped.
+// The default behavior for this package is false.
+func WithFlattenEmbedded(input bool) FuncOption {
+	return func(o *Option) {
+		o.FlattenEmbedded = input
+	}
+}
+
+// WithSkipUnexported toggles for skipping unexported struct fields in IterateStructFields.
+// The default behavior for this package is false.
+func WithSkipUnexported(input bool) FuncOption {
+	return func(o *Option) {
+		o.SkipUnexported = input
+	}
+}
+
+// WithUnexportedAccess toggles the access to the unexported struct fields using unsafe.
+// It is used by AssignReflect, CloneWith, Walk, the extractors, and the struct iterations.
+// The unexported fields can only be accessed if their parent struct is addressable, e.g. passed as a pointer,
+// except for CloneWith, Walk, and the struct iterations that copy the non-addressable struct first.
+// The default behavior for this package is false.
+func WithUnexportedAccess(input bool) FuncOption {
+	return func(o *Option) {
+		o.UnexportedAccess = input
+	}
+}
+
+// WithFieldTagFilter sets the tag key that must be present in the struct fields iterated by IterateStructFields.
+// The default behavior for this package is empty, that means all fields are iterated.
+func WithFieldTagFilter(tagKey string) FuncOption {
+	return func(o *Option) {
+		o.FieldTagFilter = tagKey
+	}
+}
+
+// WithSortedMapKeys toggles the deterministic map iteration ordered by the map keys.
+// The keys are ordered naturally, numbers numerically, strings lexically,
+// and other kinds using the result of ExtractString, unless a custom comparator is given.
+// The default behavior for this package is false.
+func WithSortedMapKeys(input bool) FuncOption {
+	return func(o *Option) {
+		o.SortMapKeys = input
+	}
+}
+
+// WithMapKeyComparator sets the custom comparator to order the map keys and toggles on the sorted map iteration.
+func WithMapKeyComparator(fn FuncComparator) FuncOption {
+	return func(o *Option) {
+		o.SortMapKeys = true
+		o.MapKeyComparator = fn
+	}
+}
+
+// WithNilOrder sets the order policy of nil values when sorting.
+// The default behavior for this package is NilFirst.
+func WithNilOrder(order NilOrder) FuncOption {
+	return func(o *Option) {
+		o.NilOrder = order
+	}
+}
+
+// WithNaturalStringOrder toggles the natural string comparison, where the digit sequences are compared as numbers,
+// e.g. "file2" < "file10". It is used by Compare, SortByFields, and the sorted map iteration.
+// The default behavior for this package is false.
+func WithNaturalStringOrder(input bool) FuncOption {
+	return func(o *Option) {
+		o.NaturalStringOrder = input
+	}
+}
+
+// WithMergeStrategy sets the global strategy used by Merge.
+// The strategy can be overridden per field using the merge struct tag, e.g. `merge:"nonzero,append"`.
+// The default behavior for this package is MergeOverwrite.
+func WithMergeStrategy(strategy MergeStrategy) FuncOption {
+	return func(o *Option) {
+		o.MergeStrategy = strategy
+	}
+}
+
+// WithTypeMerger sets the custom merger used by Merge for the dst having the typ.
+// The default behavior for this package is merging the value based on its kind.
+func WithTypeMerger(typ reflect.Type, fn FuncMerger) FuncOption {
+	return func(o *Option) {
+		if typ == nil {
+			return
+		}
+		mergers := make(map[reflect.Type]FuncMerger, len(o.TypeMergers)+1)
+		for key, val := range o.TypeMergers {
+			mergers[key] = val
+		}
+		if fn == nil {
+			delete(mergers, typ)
+		} else {
+			mergers[typ] = fn
+		}
+		o.TypeMergers = mergers
+	}
+}
+
 // WithDecoderConfig assigns the mapstructure decoder config for map assignment.
 // DecoderConfig will be assigned Result (output) in the process of assignment.
 func WithDecoderConfig(cfg *mapstructure.DecoderConfig) FuncOption {
@@ -120,6 +431,31 @@ func WithCustomAssigner(fn FuncAssigner, continueAssignOnErr bool) FuncOption {
 	}
 }
 
+// WithCloneMaxDepth sets the maximum depth copied by CloneWith.
+// Values nested deeper than maxDepth are shared with the source instead of being copied.
+// The default behavior for this package is 0, that means there is no depth limit.
+func WithCloneMaxDepth(maxDepth int) FuncOption {
+	return func(o *Option) {
+		o.CloneMaxDepth = maxDepth
+	}
+}
+
+// WithCloneShallowTypes sets the list of types that are treated as immutable by CloneWith.
+// Values of these types, or pointers to these types, are shared with the source instead of being copied.
+func WithCloneShallowTypes(types ...reflect.Type) FuncOption {
+	return func(o *Option) {
+		o.CloneShallowTypes = types
+	}
+}
+
+// WithCloneSkipPaths sets the struct field paths that are zeroed by CloneWith, e.g. "Config.Password".
+// Elements of slices, arrays, and maps don't add any segment to the path.
+func WithCloneSkipPaths(paths ...string) FuncOption {
+	return func(o *Option) {
+		o.CloneSkipPaths = paths
+	}
+}
+
 // NewDefaultOption initialize the new default option.
 func NewDefaultOption() *Option {
 	return new(Option).Default()
@@ -142,6 +478,11 @@ func (o *Option) Assign(fnOpts ...FuncOption) *Option {
 func (o *Option) Clone() *Option {
 	newOpt := deepcopy.DeepCopy(*o).(Option)
 	newOpt.hasCheckExtractValid = o.hasCheckExtractValid
+	// reflect.Type can't be deep copied because its underlying fields are unexported.
+	newOpt.CloneShallowTypes = append([]reflect.Type(nil), o.CloneShallowTypes...)
+	newOpt.TypeMergers = o.TypeMergers
+	// The identity of time.Location matters, e.g. time.Local is loaded lazily.
+	newOpt.Location = o.Location
 	return &newOpt
 }
 
@@ -0,0 +1,131 @@
+package reflecthelper
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+
+	"github.com/shopspring/decimal"
+)
+
+// OverflowPolicy is the policy applied when the number overflows the destination type.
+type OverflowPolicy uint8
+
+// List of all OverflowPolicy.
+const (
+	// OverflowReturnError returns the *OverflowError.
+	OverflowReturnError OverflowPolicy = iota
+	// OverflowSaturate clamps the number to the minimum or the maximum of the destination type,
+	// e.g. 300 is 127 for int8 and -1 is 0 for uint8.
+	OverflowSaturate
+	// OverflowWrap wraps the integer around the bit size of the destination type, e.g. 300 is 44 for int8 and -1 is 255 for uint8.
+	// The float overflowing the float32 becomes the infinity as in the Go conversion.
+	OverflowWrap
+)
+
+// wrapDecimal wraps the integer part of dec around the bitSize.
+func wrapDecimal(dec decimal.Decimal, bitSize int, signed bool) decimal.Decimal {
+	modulus := new(big.Int).Lsh(big.NewInt(1), uint(bitSize))
+	res := new(big.Int).Mod(dec.BigInt(), modulus)
+	if signed && res.Cmp(new(big.Int).Rsh(modulus, 1)) >= 0 {
+		res.Sub(res, modulus)
+	}
+	return decimal.NewFromBigInt(res, 0)
+}
+
+// handleDecimalOverflow applies the overflow policy to dec if it is outside of the min and the max.
+func handleDecimalOverflow(val reflect.Value, dec decimal.Decimal, min decimal.Decimal, max decimal.Decimal, dstType reflect.Type, option *Option) (res decimal.Decimal, err error) {
+	res = dec
+	if !dec.LessThan(min) && !dec.GreaterThan(max) {
+		return
+	}
+
+	switch option.OverflowPolicy {
+	case OverflowSaturate:
+		res = max
+		if dec.LessThan(min) {
+			res = min
+		}
+	case OverflowWrap:
+		res = wrapDecimal(dec, option.BitSize, min.Sign() < 0)
+	default:
+		err = getErrOverflowExtract(val, dstType)
+	}
+	return
+}
+
+func handleIntOverflow(assigner reflect.Value, val reflect.Value, input int64, opt *Option) (result int64, err error) {
+	result = input
+	if !assigner.OverflowInt(input) {
+		return
+	}
+
+	switch opt.OverflowPolicy {
+	case OverflowSaturate:
+		bitSize := assigner.Type().Bits()
+		result = int64(1)<<(bitSize-1) - 1
+		if input < 0 {
+			result = -1 << (bitSize - 1)
+		}
+	case OverflowWrap:
+		// SetInt truncates the input to the bit size of the assigner.
+	default:
+		err = getErrOverflow(assigner, val)
+	}
+	return
+}
+
+func handleUintOverflow(assigner reflect.Value, val reflect.Value, input uint64, opt *Option) (result uint64, err error) {
+	result = input
+	if !assigner.OverflowUint(input) {
+		return
+	}
+
+	switch opt.OverflowPolicy {
+	case OverflowSaturate:
+		result = math.MaxUint64 >> (64 - assigner.Type().Bits())
+	case OverflowWrap:
+		// SetUint truncates the input to the bit size of the assigner.
+	default:
+		err = getErrOverflow(assigner, val)
+	}
+	return
+}
+
+func saturateFloat32(input float64) float64 {
+	return math.Max(-math.MaxFloat32, math.Min(math.MaxFloat32, input))
+}
+
+func handleFloatOverflow(assigner reflect.Value, val reflect.Value, input float64, opt *Option) (result float64, err error) {
+	result = input
+	if !assigner.OverflowFloat(input) {
+		return
+	}
+
+	switch opt.OverflowPolicy {
+	case OverflowSaturate:
+		result = saturateFloat32(input)
+	case OverflowWrap:
+		// SetFloat converts the input to the infinity.
+	default:
+		err = getErrOverflow(assigner, val)
+	}
+	return
+}
+
+func handleComplexOverflow(assigner reflect.Value, val reflect.Value, input complex128, opt *Option) (result complex128, err error) {
+	result = input
+	if !assigner.OverflowComplex(input) {
+		return
+	}
+
+	switch opt.OverflowPolicy {
+	case OverflowSaturate:
+		result = complex(saturateFloat32(real(input)), saturateFloat32(imag(input)))
+	case OverflowWrap:
+		// SetComplex converts the overflowed parts to the infinity.
+	default:
+		err = getErrOverflow(assigner, val)
+	}
+	return
+}
@@ -0,0 +1,83 @@
+package reflecthelper
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverflowPolicyAssign(t *testing.T) {
+	type target struct {
+		Int8    int8
+		Uint8   uint8
+		Float32 float32
+		Complex complex64
+	}
+	tests := []struct {
+		name   string
+		field  string
+		input  interface{}
+		policy OverflowPolicy
+		want   interface{}
+	}{
+		{name: "saturate int8 max", field: "Int8", input: 300, policy: OverflowSaturate, want: int8(127)},
+		{name: "saturate int8 min", field: "Int8", input: -300, policy: OverflowSaturate, want: int8(-128)},
+		{name: "wrap int8", field: "Int8", input: 300, policy: OverflowWrap, want: int8(44)},
+		{name: "saturate uint8 max", field: "Uint8", input: uint64(1000), policy: OverflowSaturate, want: uint8(255)},
+		{name: "saturate negative uint8", field: "Uint8", input: -5, policy: OverflowSaturate, want: uint8(0)},
+		{name: "wrap negative uint8", field: "Uint8", input: -1, policy: OverflowWrap, want: uint8(255)},
+		{name: "saturate negative float to uint8", field: "Uint8", input: -2.0, policy: OverflowSaturate, want: uint8(0)},
+		{name: "wrap negative string to uint8", field: "Uint8", input: "-2", policy: OverflowWrap, want: uint8(254)},
+		{name: "saturate float32", field: "Float32", input: 1e300, policy: OverflowSaturate, want: float32(math.MaxFloat32)},
+		{name: "wrap float32", field: "Float32", input: -1e300, policy: OverflowWrap, want: float32(math.Inf(-1))},
+		{
+			name:   "saturate complex64",
+			field:  "Complex",
+			input:  complex(1e300, -1e300),
+			policy: OverflowSaturate,
+			want:   complex64(complex(math.MaxFloat32, -math.MaxFloat32)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var res target
+			field := reflect.ValueOf(&res).Elem().FieldByName(tt.field)
+			err := AssignReflect(field, reflect.ValueOf(tt.input), WithOverflowPolicy(tt.policy))
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, field.Interface())
+
+			err = AssignReflect(field, reflect.ValueOf(tt.input))
+			var overflowErr *OverflowError
+			assert.True(t, errors.As(err, &overflowErr))
+		})
+	}
+}
+
+func TestOverflowPolicyExtract(t *testing.T) {
+	got, err := ExtractInt(reflect.ValueOf(uint64(math.MaxUint64)), WithOverflowPolicy(OverflowSaturate))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MaxInt64), got)
+
+	got, err = ExtractInt(reflect.ValueOf(uint64(math.MaxUint64)), WithOverflowPolicy(OverflowWrap))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), got)
+
+	got, err = ExtractInt(reflect.ValueOf("99999999999999999999"), WithOverflowPolicy(OverflowSaturate))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MaxInt64), got)
+
+	gotUint, err := ExtractUint(reflect.ValueOf(-1), WithOverflowPolicy(OverflowWrap))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), gotUint)
+
+	gotUint, err = ExtractUint(reflect.ValueOf(1e30), WithOverflowPolicy(OverflowSaturate))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), gotUint)
+
+	_, err = ExtractUint(reflect.ValueOf(-1), WithOverflowPolicy(OverflowSaturate), WithStrictMode(StrictNegativeToUint))
+	var strictErr *StrictError
+	assert.True(t, errors.As(err, &strictErr))
+}
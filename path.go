@@ -0,0 +1,254 @@
+package reflecthelper
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PathKind is the kind of a single segment inside the Path.
+type PathKind uint8
+
+// List of all PathKind used in this package.
+const (
+	PathField PathKind = iota + 1
+	PathIndex
+	PathMapKey
+)
+
+// PathSegment is a single step from a parent value to its child value.
+type PathSegment struct {
+	Kind  PathKind
+	Name  string
+	Index int
+	Key   reflect.Value
+}
+
+// String returns the string representation of the segment.
+func (p PathSegment) String() string {
+	switch p.Kind {
+	case PathField:
+		return p.Name
+	case PathIndex:
+		return "[" + strconv.Itoa(p.Index) + "]"
+	case PathMapKey:
+		return "[" + formatPathKey(p.Key) + "]"
+	}
+	return ""
+}
+
+func formatPathKey(key reflect.Value) string {
+	if !key.IsValid() || !key.CanInterface() {
+		return ""
+	}
+	if key.Kind() == reflect.String {
+		return strconv.Quote(key.String())
+	}
+	return fmt.Sprintf("%v", key.Interface())
+}
+
+// Path is the location of a value from the root value, e.g. Address.City or Items[0].Tags["key"].
+type Path []PathSegment
+
+// String returns the string representation of the path.
+func (p Path) String() string {
+	var builder strings.Builder
+	for index, segment := range p {
+		if segment.Kind == PathField && index > 0 {
+			builder.WriteByte('.')
+		}
+		builder.WriteString(segment.String())
+	}
+	return builder.String()
+}
+
+// Last returns the last segment of the path.
+// It returns an empty PathSegment if the path is empty.
+func (p Path) Last() (res PathSegment) {
+	if len(p) == 0 {
+		return
+	}
+
+	res = p[len(p)-1]
+	return
+}
+
+func (p Path) append(segment PathSegment) (res Path) {
+	res = make(Path, len(p)+1)
+	copy(res, p)
+	res[len(p)] = segment
+	return
+}
+
+// ParsePath parses the input string to the Path, e.g. Address.City, Items[0].Name, or Meta["key"].
+// The map key inside the brackets can be quoted by double or single quote.
+func ParsePath(input string) (res Path, err error) {
+	res = make(Path, 0)
+	for position := 0; position < len(input); {
+		switch input[position] {
+		case '.':
+			position++
+		case '[':
+			end := strings.IndexByte(input[position:], ']')
+			if end < 0 {
+				err = getErrInvalidPath(input, position)
+				return
+			}
+			var segment PathSegment
+			segment, err = parsePathBracket(input[position+1 : position+end])
+			if err != nil {
+				err = getErrInvalidPath(input, position)
+				return
+			}
+			res = append(res, segment)
+			position += end + 1
+		default:
+			end := strings.IndexAny(input[position:], ".[")
+			if end < 0 {
+				end = len(input) - position
+			}
+			res = append(res, PathSegment{Kind: PathField, Name: input[position : position+end]})
+			position += end
+		}
+	}
+	return
+}
+
+func parsePathBracket(content string) (res PathSegment, err error) {
+	content = strings.TrimSpace(content)
+	if len(content) >= 2 && (content[0] == '"' || content[0] == '\'') && content[len(content)-1] == content[0] {
+		res = PathSegment{Kind: PathMapKey, Key: reflect.ValueOf(content[1 : len(content)-1])}
+		return
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return
+	}
+	res = PathSegment{Kind: PathIndex, Index: index}
+	return
+}
+
+// LookupPath gets the value located at the path from val.
+// The pointers and interfaces are dereferenced along the path.
+// The struct field is matched by its name, then by its name case-insensitively.
+// The map key is converted to the key type of the map using AssignReflect.
+func LookupPath(val reflect.Value, path string, fnOpts ...FuncOption) (res reflect.Value, err error) {
+	segments, err := ParsePath(path)
+	if err != nil {
+		return
+	}
+
+	res, err = lookupPath(val, segments, NewOption().Assign(fnOpts...))
+	return
+}
+
+func lookupPath(val reflect.Value, path Path, opt *Option) (res reflect.Value, err error) {
+	res = val
+	for index, segment := range path {
+		res = GetChildElem(res)
+		if !res.IsValid() || IsValueNil(res) {
+			err = getErrPathNil(path[:index])
+			return
+		}
+
+		res, err = lookupPathSegment(res, segment, opt)
+		if err != nil {
+			return
+		}
+		if !res.IsValid() {
+			err = getErrPathNotFound(path[:index+1])
+			return
+		}
+	}
+	return
+}
+
+func lookupPathSegment(val reflect.Value, segment PathSegment, opt *Option) (res reflect.Value, err error) {
+	switch GetKind(val) {
+	case reflect.Struct:
+		if segment.Kind == PathField {
+			res = getFieldByName(val, segment.Name)
+		}
+	case reflect.Array, reflect.Slice:
+		if segment.Kind == PathIndex && segment.Index >= 0 && segment.Index < val.Len() {
+			res = val.Index(segment.Index)
+		}
+	case reflect.Map:
+		var key reflect.Value
+		key, err = getPathMapKey(val.Type().Key(), segment, opt)
+		if err != nil {
+			return
+		}
+		res = val.MapIndex(key)
+	}
+	return
+}
+
+func getStructFieldByName(typ reflect.Type, name string) (res reflect.StructField, ok bool) {
+	res, ok = typ.FieldByName(name)
+	if ok {
+		return
+	}
+
+	res, ok = typ.FieldByNameFunc(func(fieldName string) bool {
+		return strings.EqualFold(fieldName, name)
+	})
+	return
+}
+
+func getFieldByName(val reflect.Value, name string) (res reflect.Value) {
+	field, ok := getStructFieldByName(val.Type(), name)
+	if !ok {
+		return
+	}
+
+	res, _ = getFieldByIndex(val, field.Index)
+	return
+}
+
+func getPathMapKey(keyType reflect.Type, segment PathSegment, opt *Option) (res reflect.Value, err error) {
+	var key reflect.Value
+	switch segment.Kind {
+	case PathField:
+		key = reflect.ValueOf(segment.Name)
+	case PathIndex:
+		key = reflect.ValueOf(segment.Index)
+	case PathMapKey:
+		key = segment.Key
+	}
+
+	res = reflect.New(keyType).Elem()
+	err = assignReflect(res, key, opt)
+	return
+}
+
+// getPathType gets the static type located at the path from typ.
+// The resolution stops at the interface type because its dynamic type is unknown.
+func getPathType(typ reflect.Type, path Path) (res reflect.Type, ok bool) {
+	res = typ
+	for _, segment := range path {
+		res = GetTypeChildElemPtrType(res)
+		switch res.Kind() {
+		case reflect.Interface:
+			ok = true
+			return
+		case reflect.Struct:
+			if segment.Kind != PathField {
+				return
+			}
+			field, found := getStructFieldByName(res, segment.Name)
+			if !found {
+				return
+			}
+			res = field.Type
+		case reflect.Array, reflect.Slice, reflect.Map:
+			res = res.Elem()
+		default:
+			return
+		}
+	}
+	ok = true
+	return
+}
@@ -0,0 +1,179 @@
+package reflecthelper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPath_String(t *testing.T) {
+	tests := []struct {
+		name string
+		path Path
+		want string
+	}{
+		{
+			name: "empty path",
+			path: nil,
+			want: "",
+		},
+		{
+			name: "nested field",
+			path: Path{
+				{Kind: PathField, Name: "Address"},
+				{Kind: PathField, Name: "City"},
+			},
+			want: "Address.City",
+		},
+		{
+			name: "index and map key",
+			path: Path{
+				{Kind: PathField, Name: "Items"},
+				{Kind: PathIndex, Index: 2},
+				{Kind: PathField, Name: "Tags"},
+				{Kind: PathMapKey, Key: reflect.ValueOf("key")},
+				{Kind: PathMapKey, Key: reflect.ValueOf(10)},
+			},
+			want: `Items[2].Tags["key"][10]`,
+		},
+		{
+			name: "invalid map key",
+			path: Path{
+				{Kind: PathMapKey},
+			},
+			want: "[]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.path.String())
+		})
+	}
+}
+
+func TestPath_Last(t *testing.T) {
+	assert.Equal(t, PathSegment{}, Path(nil).Last())
+	path := Path{{Kind: PathField, Name: "A"}, {Kind: PathIndex, Index: 1}}
+	assert.Equal(t, PathIndex, path.Last().Kind)
+
+	appended := path.append(PathSegment{Kind: PathField, Name: "B"})
+	assert.Len(t, path, 2)
+	assert.Equal(t, "A[1].B", appended.String())
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty path", input: "", want: ""},
+		{name: "fields", input: "Address.City", want: "Address.City"},
+		{name: "index and keys", input: `Items[0].Tags["a.b"]['c']`, want: `Items[0].Tags["a.b"]["c"]`},
+		{name: "leading index", input: "[1].Name", want: "[1].Name"},
+		{name: "unclosed bracket", input: "Items[0", wantErr: true},
+		{name: "invalid index", input: "Items[abc]", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePath(tt.input)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+type pathAddress struct {
+	City string
+}
+
+type pathEmbedded struct {
+	Code string
+}
+
+type pathUser struct {
+	*pathEmbedded
+	Name    string
+	Address *pathAddress
+	Tags    []string
+	Meta    map[string]interface{}
+	Scores  map[int]float64
+	Any     interface{}
+}
+
+func TestLookupPath(t *testing.T) {
+	user := &pathUser{
+		Name:    "john",
+		Address: &pathAddress{City: "Jakarta"},
+		Tags:    []string{"admin", "user"},
+		Meta:    map[string]interface{}{"level": 3, "nested": map[string]string{"key": "value"}},
+		Scores:  map[int]float64{1: 9.5},
+		Any:     pathAddress{City: "Bandung"},
+	}
+	tests := []struct {
+		name    string
+		path    string
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "root", path: "", want: user},
+		{name: "nested pointer field", path: "Address.City", want: "Jakarta"},
+		{name: "case insensitive field", path: "address.city", want: "Jakarta"},
+		{name: "slice index", path: "Tags[1]", want: "user"},
+		{name: "map key by field", path: "Meta.level", want: 3},
+		{name: "nested map key", path: `Meta["nested"]["key"]`, want: "value"},
+		{name: "int map key", path: "Scores[1]", want: 9.5},
+		{name: "interface field", path: "Any.City", want: "Bandung"},
+		{name: "out of range index", path: "Tags[5]", wantErr: true},
+		{name: "unknown field", path: "Unknown", wantErr: true},
+		{name: "nil embedded pointer", path: "Code", wantErr: true},
+		{name: "invalid map key", path: "Scores.abc", wantErr: true},
+		{name: "field on string", path: "Name.Length", wantErr: true},
+		{name: "invalid path", path: "Tags[", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LookupPath(reflect.ValueOf(user), tt.path)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got.Interface())
+		})
+	}
+	t.Run("nil value in the middle", func(t *testing.T) {
+		_, err := LookupPath(reflect.ValueOf(&pathUser{}), "Address.City")
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_getPathType(t *testing.T) {
+	typ := reflect.TypeOf(pathUser{})
+	segments, _ := ParsePath("Address.City")
+	res, ok := getPathType(typ, segments)
+	assert.True(t, ok)
+	assert.Equal(t, reflect.TypeOf(""), res)
+
+	segments, _ = ParsePath("Any.City")
+	res, ok = getPathType(typ, segments)
+	assert.True(t, ok)
+	assert.Equal(t, reflect.Interface, res.Kind())
+
+	segments, _ = ParsePath("Scores[1]")
+	res, ok = getPathType(typ, segments)
+	assert.True(t, ok)
+	assert.Equal(t, reflect.TypeOf(float64(0)), res)
+
+	for _, path := range []string{"Unknown", "Name.Length", "[0]"} {
+		segments, _ = ParsePath(path)
+		_, ok = getPathType(typ, segments)
+		assert.False(t, ok, path)
+	}
+}
@@ -1,6 +1,90 @@
 package reflecthelper
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// PanicKind is the classification of the recovered panic.
+type PanicKind uint8
+
+// List of all PanicKind used in this package.
+const (
+	PanicNone PanicKind = iota
+	PanicOther
+	PanicUnexportedField
+	PanicUnaddressable
+	PanicNilPointer
+	PanicValueError
+)
+
+// PanicError is the error recovered from a panic along with the captured stack.
+type PanicError struct {
+	// Value is the raw value returned from recover.
+	Value interface{}
+	// Stack is the stack trace of the goroutine when the panic is recovered.
+	Stack []byte
+}
+
+func newPanicError(rec interface{}) *PanicError {
+	return &PanicError{
+		Value: rec,
+		Stack: debug.Stack(),
+	}
+}
+
+// Error returns the message of the recovered value.
+func (e *PanicError) Error() string {
+	if err, ok := e.Value.(error); ok {
+		return err.Error()
+	}
+	return fmt.Sprintf("%v", e.Value)
+}
+
+// Unwrap returns the recovered value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Kind classifies the recovered panic.
+// PanicNilPointer covers the nil pointer dereference and the method call on the zero reflect.Value.
+func (e *PanicError) Kind() PanicKind {
+	msg := e.Error()
+	switch {
+	case strings.Contains(msg, "unexported field"):
+		return PanicUnexportedField
+	case strings.Contains(msg, "unaddressable"):
+		return PanicUnaddressable
+	}
+
+	var runtimeErr runtime.Error
+	if errors.As(e.Unwrap(), &runtimeErr) && strings.Contains(msg, "nil pointer dereference") {
+		return PanicNilPointer
+	}
+	var valueErr *reflect.ValueError
+	if errors.As(e.Unwrap(), &valueErr) {
+		if valueErr.Kind == reflect.Invalid {
+			return PanicNilPointer
+		}
+		return PanicValueError
+	}
+	return PanicOther
+}
+
+// GetPanicKind gets the PanicKind of the PanicError inside the err.
+// It returns PanicNone if the err doesn't contain any PanicError.
+func GetPanicKind(err error) PanicKind {
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		return PanicNone
+	}
+	return panicErr.Kind()
+}
 
 // recoverFnOpt is used to recover from panic using the opt.RecoverPanic toggle.
 func recoverFnOpt(err *error, opt *Option) {
@@ -9,27 +93,40 @@ func recoverFnOpt(err *error, opt *Option) {
 	}
 
 	if rec := recover(); rec != nil {
-		switch val := rec.(type) {
-		case error:
-			*err = val
-		default:
-			*err = fmt.Errorf("%v", val)
-		}
+		*err = newPanicError(rec)
 	}
 }
 
 // RecoverFn is used to recover from panic situation by passing the pointer of the error.
+// The recovered panic is assigned to the error as *PanicError.
 func RecoverFn(err *error) {
 	if err == nil {
 		return
 	}
 
 	if rec := recover(); rec != nil {
-		switch val := rec.(type) {
-		case error:
-			*err = val
-		default:
-			*err = fmt.Errorf("%v", val)
-		}
+		*err = newPanicError(rec)
 	}
 }
+
+// SafeCall calls the fn and returns the panic inside the fn as *PanicError.
+func SafeCall(fn func() error) (err error) {
+	if fn == nil {
+		return
+	}
+
+	defer RecoverFn(&err)
+	err = fn()
+	return
+}
+
+// SafeGo calls the fn in a new goroutine using SafeCall so that the panic inside the fn can't crash the process.
+// The returned channel receives the error of the fn and then is closed.
+func SafeGo(fn func() error) <-chan error {
+	chErr := make(chan error, 1)
+	go func() {
+		defer close(chErr)
+		chErr <- SafeCall(fn)
+	}()
+	return chErr
+}
@@ -2,7 +2,10 @@ package reflecthelper
 
 import (
 	"errors"
+	"reflect"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_RecoverFn(t *testing.T) {
@@ -20,3 +23,110 @@ func Test_RecoverFn(t *testing.T) {
 		panic(errors.New("hello"))
 	})
 }
+
+func TestSafeCall(t *testing.T) {
+	t.Run("nil fn", func(t *testing.T) {
+		assert.Nil(t, SafeCall(nil))
+	})
+	t.Run("returned error", func(t *testing.T) {
+		assert.Equal(t, ErrTesting, SafeCall(func() error { return ErrTesting }))
+	})
+	t.Run("error panic", func(t *testing.T) {
+		err := SafeCall(func() error { panic(ErrTesting) })
+		var panicErr *PanicError
+		assert.True(t, errors.As(err, &panicErr))
+		assert.True(t, errors.Is(err, ErrTesting))
+		assert.Equal(t, ErrTesting, panicErr.Value)
+		assert.Equal(t, ErrTesting.Error(), err.Error())
+		assert.Contains(t, string(panicErr.Stack), "TestSafeCall")
+		assert.Equal(t, PanicOther, GetPanicKind(err))
+	})
+	t.Run("non error panic", func(t *testing.T) {
+		err := SafeCall(func() error { panic(42) })
+		assert.Equal(t, "42", err.Error())
+		assert.Nil(t, errors.Unwrap(err))
+		assert.Equal(t, PanicOther, GetPanicKind(err))
+	})
+}
+
+func TestSafeGo(t *testing.T) {
+	err := <-SafeGo(func() error { panic("goroutine panic") })
+	assert.Equal(t, "goroutine panic", err.Error())
+	assert.Nil(t, <-SafeGo(func() error { return nil }))
+}
+
+func TestGetPanicKind(t *testing.T) {
+	type test struct {
+		private int
+	}
+	tests := []struct {
+		name string
+		fn   func() error
+		want PanicKind
+	}{
+		{
+			name: "no panic",
+			fn:   func() error { return ErrTesting },
+			want: PanicNone,
+		},
+		{
+			name: "unexported field",
+			fn: func() error {
+				_ = reflect.ValueOf(test{}).Field(0).Interface()
+				return nil
+			},
+			want: PanicUnexportedField,
+		},
+		{
+			name: "unaddressable",
+			fn: func() error {
+				reflect.ValueOf(0).SetInt(1)
+				return nil
+			},
+			want: PanicUnaddressable,
+		},
+		{
+			name: "nil pointer dereference",
+			fn: func() error {
+				var ptr *test
+				_ = ptr.private
+				return nil
+			},
+			want: PanicNilPointer,
+		},
+		{
+			name: "zero reflect value",
+			fn: func() error {
+				_ = reflect.ValueOf((*test)(nil)).Elem().Field(0)
+				return nil
+			},
+			want: PanicNilPointer,
+		},
+		{
+			name: "reflect value error",
+			fn: func() error {
+				_ = reflect.ValueOf(0).Len()
+				return nil
+			},
+			want: PanicValueError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetPanicKind(SafeCall(tt.fn)))
+		})
+	}
+}
+
+func TestValue_IterateConcurrentPanic(t *testing.T) {
+	val := Cast(reflect.ValueOf([]int{1, 2, 3}), WithConcurrency(true))
+	err := val.IterateArraySlice(func(parent reflect.Value, index int, elem reflect.Value) error {
+		if index == 1 {
+			panic("concurrent panic")
+		}
+		return nil
+	}).Error()
+	var panicErr *PanicError
+	assert.True(t, errors.As(err, &panicErr))
+	assert.Equal(t, "concurrent panic", panicErr.Value)
+}
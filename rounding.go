@@ -0,0 +1,140 @@
+package reflecthelper
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// RoundingMode is the mode used to convert the fractional number to the integer.
+type RoundingMode uint8
+
+// List of all RoundingMode.
+const (
+	// RoundError returns the error if the number has the fractional part.
+	RoundError RoundingMode = iota
+	// RoundTruncate discards the fractional part, e.g. -2.7 is -2.
+	RoundTruncate
+	// RoundHalfEven rounds to the nearest integer and the half to the even integer, e.g. 2.5 is 2 and 3.5 is 4.
+	RoundHalfEven
+	// RoundHalfUp rounds to the nearest integer and the half away from zero, e.g. 2.5 is 3 and -2.5 is -3.
+	RoundHalfUp
+	// RoundFloor rounds toward negative infinity, e.g. -2.1 is -3.
+	RoundFloor
+	// RoundCeil rounds toward positive infinity, e.g. 2.1 is 3.
+	RoundCeil
+)
+
+func roundDecimal(val reflect.Value, dec decimal.Decimal, dstType reflect.Type, option *Option) (res decimal.Decimal, err error) {
+	switch option.RoundingMode {
+	case RoundTruncate:
+		res = dec.Truncate(0)
+	case RoundHalfEven:
+		res = dec.RoundBank(0)
+	case RoundHalfUp:
+		res = dec.Round(0)
+	case RoundFloor:
+		res = dec.Floor()
+	case RoundCeil:
+		res = dec.Ceil()
+	default:
+		res = dec.Truncate(0)
+		if !res.Equal(dec) {
+			err = getErrFractional(val, dstType)
+		}
+	}
+	return
+}
+
+// getFloatDecimal converts the float of val to the decimal using the shortest representation of its bit size.
+func getFloatDecimal(val reflect.Value, dstType reflect.Type) (res decimal.Decimal, err error) {
+	floatVal := val.Float()
+	if math.IsNaN(floatVal) || math.IsInf(floatVal, 0) {
+		err = getErrOverflowExtract(val, dstType)
+		return
+	}
+
+	if GetKind(val) == reflect.Float32 {
+		res = decimal.NewFromFloat32(float32(floatVal))
+		return
+	}
+	res = decimal.NewFromFloat(floatVal)
+	return
+}
+
+// getNumericDecimal parses the decimal string, e.g. 12.0, 1e3, or the out of range integer,
+// the ok is false if the str is not a decimal number.
+func getNumericDecimal(str string, cause error, option *Option) (res decimal.Decimal, ok bool) {
+	var numErr *strconv.NumError
+	if option.BaseSystem != DefaultBaseSystem || !errors.As(cause, &numErr) {
+		return
+	}
+
+	res, err := decimal.NewFromString(strings.TrimSpace(str))
+	ok = err == nil
+	return
+}
+
+func getIntRange(bitSize int) (min decimal.Decimal, max decimal.Decimal) {
+	min = decimal.NewFromInt(-1 << (bitSize - 1))
+	max = decimal.NewFromInt(1<<(bitSize-1) - 1)
+	return
+}
+
+func getUintMax(bitSize int) decimal.Decimal {
+	return decimal.NewFromBigInt(new(big.Int).SetUint64(math.MaxUint64>>(64-bitSize)), 0)
+}
+
+func roundDecimalToInt(val reflect.Value, dec decimal.Decimal, option *Option) (result int64, err error) {
+	dec, err = roundDecimal(val, dec, TypeInt64, option)
+	if err != nil {
+		return
+	}
+
+	min, max := getIntRange(option.BitSize)
+	dec, err = handleDecimalOverflow(val, dec, min, max, TypeInt64, option)
+	if err != nil {
+		return
+	}
+	result = dec.IntPart()
+	return
+}
+
+func roundDecimalToUint(val reflect.Value, dec decimal.Decimal, option *Option) (result uint64, err error) {
+	dec, err = roundDecimal(val, dec, TypeUint64, option)
+	if err != nil {
+		return
+	}
+
+	dec, err = handleDecimalOverflow(val, dec, decimal.Zero, getUintMax(option.BitSize), TypeUint64, option)
+	if err != nil {
+		return
+	}
+	result = dec.BigInt().Uint64()
+	return
+}
+
+func roundFloatToInt(val reflect.Value, option *Option) (result int64, err error) {
+	dec, err := getFloatDecimal(val, TypeInt64)
+	if err != nil {
+		return
+	}
+
+	result, err = roundDecimalToInt(val, dec, option)
+	return
+}
+
+func roundFloatToUint(val reflect.Value, option *Option) (result uint64, err error) {
+	dec, err := getFloatDecimal(val, TypeUint64)
+	if err != nil {
+		return
+	}
+
+	result, err = roundDecimalToUint(val, dec, option)
+	return
+}
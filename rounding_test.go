@@ -0,0 +1,87 @@
+package reflecthelper
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundingMode(t *testing.T) {
+	tests := []struct {
+		input interface{}
+		mode  RoundingMode
+		want  int64
+	}{
+		{input: 2.7, mode: RoundTruncate, want: 2},
+		{input: -2.7, mode: RoundTruncate, want: -2},
+		{input: 2.5, mode: RoundHalfEven, want: 2},
+		{input: 3.5, mode: RoundHalfEven, want: 4},
+		{input: -2.5, mode: RoundHalfEven, want: -2},
+		{input: 2.5, mode: RoundHalfUp, want: 3},
+		{input: -2.5, mode: RoundHalfUp, want: -3},
+		{input: 2.4, mode: RoundHalfUp, want: 2},
+		{input: -2.1, mode: RoundFloor, want: -3},
+		{input: 2.1, mode: RoundCeil, want: 3},
+		{input: float32(2.7), mode: RoundTruncate, want: 2},
+		{input: "12.0", mode: RoundError, want: 12},
+		{input: " 12.5 ", mode: RoundHalfUp, want: 13},
+		{input: "1e3", mode: RoundError, want: 1000},
+		{input: 4.0, mode: RoundError, want: 4},
+	}
+	for _, tt := range tests {
+		got, err := ExtractInt(reflect.ValueOf(tt.input), WithRoundingMode(tt.mode))
+		assert.Nil(t, err, "input: %v mode: %d", tt.input, tt.mode)
+		assert.Equal(t, tt.want, got, "input: %v mode: %d", tt.input, tt.mode)
+	}
+}
+
+func TestRoundingModeError(t *testing.T) {
+	_, err := ExtractInt(reflect.ValueOf(2.7))
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+
+	_, err = ExtractUint(reflect.ValueOf("2.7"))
+	assert.True(t, errors.As(err, &parseErr))
+
+	_, err = ExtractInt(reflect.ValueOf("2.7x"), WithRoundingMode(RoundTruncate))
+	assert.True(t, errors.As(err, &parseErr))
+
+	var overflowErr *OverflowError
+	_, err = ExtractInt(reflect.ValueOf(1e30), WithRoundingMode(RoundTruncate))
+	assert.True(t, errors.As(err, &overflowErr))
+
+	_, err = ExtractInt(reflect.ValueOf(math.NaN()), WithRoundingMode(RoundTruncate))
+	assert.True(t, errors.As(err, &overflowErr))
+
+	_, err = ExtractUint(reflect.ValueOf(-0.7), WithRoundingMode(RoundFloor))
+	assert.True(t, errors.As(err, &overflowErr))
+
+	got, err := ExtractUint(reflect.ValueOf(-0.7), WithRoundingMode(RoundTruncate))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), got)
+
+	_, err = ExtractInt(reflect.ValueOf(2.5), WithRoundingMode(RoundHalfUp), WithStrictMode(StrictFractionalToInt))
+	var strictErr *StrictError
+	assert.True(t, errors.As(err, &strictErr))
+}
+
+func TestRoundingModeAssign(t *testing.T) {
+	var res struct {
+		Count  int8
+		Amount uint16
+	}
+	err := AssignReflect(reflect.ValueOf(&res.Count), reflect.ValueOf(99.5), WithRoundingMode(RoundHalfEven))
+	assert.Nil(t, err)
+	assert.Equal(t, int8(100), res.Count)
+
+	err = AssignReflect(reflect.ValueOf(&res.Count), reflect.ValueOf(127.9), WithRoundingMode(RoundCeil))
+	var overflowErr *OverflowError
+	assert.True(t, errors.As(err, &overflowErr))
+
+	err = AssignReflect(reflect.ValueOf(&res.Amount), reflect.ValueOf("12.0"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(12), res.Amount)
+}
@@ -0,0 +1,148 @@
+package reflecthelper
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// NilOrder is the order policy of nil values when sorting.
+type NilOrder uint8
+
+// List of all NilOrder used in this package.
+const (
+	NilFirst NilOrder = iota
+	NilLast
+)
+
+type sortField struct {
+	path Path
+	desc bool
+}
+
+func parseSortFields(fields []string) (res []sortField, err error) {
+	res = make([]sortField, 0, len(fields))
+	for _, field := range fields {
+		var desc bool
+		switch {
+		case strings.HasPrefix(field, "-"):
+			desc = true
+			field = field[1:]
+		case strings.HasPrefix(field, "+"):
+			field = field[1:]
+		}
+
+		var path Path
+		path, err = ParsePath(field)
+		if err != nil {
+			return
+		}
+		res = append(res, sortField{path: path, desc: desc})
+	}
+	return
+}
+
+// fieldSorter sorts the permutation of the list indexes, so the keys keep referring to the original elements.
+type fieldSorter struct {
+	indexes []int
+	keys    [][]reflect.Value
+	fields  []sortField
+	opt     *Option
+}
+
+func (s *fieldSorter) Len() int {
+	return len(s.indexes)
+}
+
+func (s *fieldSorter) Swap(i, j int) {
+	s.indexes[i], s.indexes[j] = s.indexes[j], s.indexes[i]
+}
+
+func (s *fieldSorter) Less(i, j int) bool {
+	leftKeys, rightKeys := s.keys[s.indexes[i]], s.keys[s.indexes[j]]
+	for index, field := range s.fields {
+		left, right := leftKeys[index], rightKeys[index]
+		leftNil, rightNil := !left.IsValid(), !right.IsValid()
+		switch {
+		case leftNil && rightNil:
+			continue
+		case leftNil:
+			return s.opt.NilOrder == NilFirst
+		case rightNil:
+			return s.opt.NilOrder == NilLast
+		}
+
+		res := compareNatural(left, right, s.opt)
+		if field.desc {
+			res = -res
+		}
+		if res != 0 {
+			return res < 0
+		}
+	}
+	return false
+}
+
+// getSortKey returns an invalid reflect.Value if the value at the path is nil or unreachable.
+func getSortKey(elem reflect.Value, path Path, opt *Option) (res reflect.Value) {
+	res, err := lookupPath(elem, path, opt)
+	if err != nil {
+		res = reflect.Value{}
+		return
+	}
+
+	res = GetChildElem(res)
+	if IsValueElemable(res) {
+		res = reflect.Value{}
+	}
+	return
+}
+
+func sortByFields(val reflect.Value, fields []string, opt *Option) (err error) {
+	val = GetChildElem(val)
+	switch GetKind(val) {
+	case reflect.Slice:
+	case reflect.Array:
+		if !val.CanAddr() {
+			err = getErrCanAddrInterface(val)
+			return
+		}
+		val = val.Slice(0, val.Len())
+	default:
+		err = getErrNotList(val)
+		return
+	}
+
+	sortFields, err := parseSortFields(fields)
+	if err != nil {
+		return
+	}
+	for _, field := range sortFields {
+		if _, ok := getPathType(val.Type().Elem(), field.path); !ok {
+			err = getErrPathNotFound(field.path)
+			return
+		}
+	}
+
+	sorter := &fieldSorter{
+		indexes: make([]int, val.Len()),
+		keys:    make([][]reflect.Value, val.Len()),
+		fields:  sortFields,
+		opt:     opt,
+	}
+	for index := range sorter.keys {
+		sorter.indexes[index] = index
+		sorter.keys[index] = make([]reflect.Value, len(sortFields))
+		for fieldIndex, field := range sortFields {
+			sorter.keys[index][fieldIndex] = getSortKey(val.Index(index), field.path, opt)
+		}
+	}
+	sort.Stable(sorter)
+
+	sorted := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+	for index, oldIndex := range sorter.indexes {
+		sorted.Index(index).Set(val.Index(oldIndex))
+	}
+	reflect.Copy(val, sorted)
+	return
+}
@@ -0,0 +1,20 @@
+package reflecthelper
+
+// SortByFields sorts the slice, or the pointer to slice or array, stably by the field paths.
+// The field path is resolved using LookupPath and it can be prefixed with "-" for the descending order.
+// e.g. SortByFields(&users, "LastName", "-CreatedAt", "Address.City").
+// The values are compared naturally, numbers numerically, strings lexically, and times chronologically.
+// The nil values are placed first.
+func SortByFields(slicePtr interface{}, fields ...string) (err error) {
+	err = SortByFieldsWith(slicePtr, fields)
+	return
+}
+
+// SortByFieldsWith is like SortByFields but it accepts function options, e.g. WithNilOrder.
+func SortByFieldsWith(slicePtr interface{}, fields []string, fnOpts ...FuncOption) (err error) {
+	opt := NewOption().Assign(fnOpts...)
+	defer recoverFnOpt(&err, opt)
+
+	err = sortByFields(getValFromInterface(slicePtr), fields, opt)
+	return
+}
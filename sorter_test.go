@@ -0,0 +1,87 @@
+package reflecthelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sortAddress struct {
+	City string
+}
+
+type sortUser struct {
+	FirstName string
+	LastName  string
+	Age       uint8
+	Score     float64
+	CreatedAt time.Time
+	Address   *sortAddress
+}
+
+func getSortUserNames(users []sortUser) (res []string) {
+	for _, user := range users {
+		res = append(res, user.FirstName)
+	}
+	return
+}
+
+func TestSortByFields(t *testing.T) {
+	base := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	newUsers := func() []sortUser {
+		return []sortUser{
+			{FirstName: "a", LastName: "smith", Age: 30, Score: 1.5, CreatedAt: base.Add(time.Hour), Address: &sortAddress{City: "Surabaya"}},
+			{FirstName: "b", LastName: "doe", Age: 25, Score: 2, CreatedAt: base, Address: &sortAddress{City: "Bandung"}},
+			{FirstName: "c", LastName: "smith", Age: 25, Score: 0.5, CreatedAt: base.Add(2 * time.Hour)},
+			{FirstName: "d", LastName: "doe", Age: 40, Score: 2, CreatedAt: base.Add(-time.Hour), Address: &sortAddress{City: "Aceh"}},
+		}
+	}
+	tests := []struct {
+		name   string
+		fields []string
+		opts   []FuncOption
+		want   []string
+	}{
+		{name: "single string field", fields: []string{"LastName"}, want: []string{"b", "d", "a", "c"}},
+		{name: "descending time", fields: []string{"LastName", "-CreatedAt"}, want: []string{"b", "d", "c", "a"}},
+		{name: "ascending prefix and number", fields: []string{"+Age", "-Score"}, want: []string{"b", "c", "a", "d"}},
+		{name: "nested field nil first", fields: []string{"Address.City"}, want: []string{"c", "d", "b", "a"}},
+		{name: "nested field nil last", fields: []string{"-Address.City"}, opts: []FuncOption{WithNilOrder(NilLast)}, want: []string{"a", "b", "d", "c"}},
+		{name: "stable with no fields", fields: nil, want: []string{"a", "b", "c", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newUsers()
+			err := SortByFieldsWith(&users, tt.fields, tt.opts...)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, getSortUserNames(users))
+		})
+	}
+	t.Run("slice value and array pointer", func(t *testing.T) {
+		users := newUsers()
+		assert.Nil(t, SortByFields(users, "-Age"))
+		assert.Equal(t, []string{"d", "a", "b", "c"}, getSortUserNames(users))
+
+		arr := [3]int{3, 1, 2}
+		assert.Nil(t, SortByFields(&arr, ""))
+		assert.Equal(t, [3]int{1, 2, 3}, arr)
+	})
+	t.Run("pointer elements and map elements", func(t *testing.T) {
+		users := []*sortUser{{FirstName: "x", Age: 2}, nil, {FirstName: "y", Age: 1}}
+		assert.Nil(t, SortByFields(&users, "Age"))
+		assert.Nil(t, users[0])
+		assert.Equal(t, "y", users[1].FirstName)
+
+		maps := []map[string]interface{}{{"n": 2}, {"n": "1"}, {}}
+		assert.Nil(t, SortByFields(maps, "n"))
+		assert.Equal(t, 0, len(maps[0]))
+		assert.Equal(t, "1", maps[1]["n"])
+	})
+	t.Run("error cases", func(t *testing.T) {
+		assert.NotNil(t, SortByFields(newUsers(), "Unknown"))
+		assert.NotNil(t, SortByFields(newUsers(), "Address["))
+		assert.NotNil(t, SortByFields(1, "Age"))
+		assert.NotNil(t, SortByFields([2]int{1, 2}, ""))
+	})
+}
@@ -0,0 +1,155 @@
+package reflecthelper
+
+import (
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// StrictPolicy is the bitmask of the coercions disallowed by WithStrictMode.
+type StrictPolicy uint8
+
+// List of all StrictPolicy.
+const (
+	// StrictStringToNumber disallows parsing the string or []byte to the number.
+	StrictStringToNumber StrictPolicy = 1 << iota
+	// StrictBoolNumber disallows converting the bool to the number and vice versa.
+	StrictBoolNumber
+	// StrictFractionalToInt disallows converting the float with the fractional part to the integer.
+	StrictFractionalToInt
+	// StrictNegativeToUint disallows converting the negative number to the unsigned integer.
+	StrictNegativeToUint
+	// StrictPrecisionLoss disallows converting the integer beyond 2^53 to the float64.
+	StrictPrecisionLoss
+
+	// StrictNone allows all coercions.
+	StrictNone StrictPolicy = 0
+	// StrictAll disallows all coercions listed in StrictPolicy.
+	StrictAll = StrictStringToNumber | StrictBoolNumber | StrictFractionalToInt | StrictNegativeToUint | StrictPrecisionLoss
+)
+
+// maxExactFloat is the maximum integer that float64 can represent exactly.
+const maxExactFloat = 1 << 53
+
+var strictPolicyNames = []struct {
+	policy StrictPolicy
+	name   string
+}{
+	{StrictStringToNumber, "string to number"},
+	{StrictBoolNumber, "bool to or from number"},
+	{StrictFractionalToInt, "fractional float to integer"},
+	{StrictNegativeToUint, "negative to unsigned integer"},
+	{StrictPrecisionLoss, "precision losing integer to float"},
+}
+
+// String returns the names of the policies, e.g. string to number|bool to or from number.
+func (p StrictPolicy) String() string {
+	var names []string
+	for _, policyName := range strictPolicyNames {
+		if p&policyName.policy != 0 {
+			names = append(names, policyName.name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+func isKindStrictNumber(kind reflect.Kind) bool {
+	return isKindNumber(kind) || IsKindComplex(kind)
+}
+
+// strictNumber describes the number converted by the strict mode.
+type strictNumber struct {
+	negative    bool
+	fractional  bool
+	beyondExact bool
+}
+
+// getStrictNumber describes the number of the numeric val or the numeric string val, the ok is false if val is not a number.
+func getStrictNumber(val reflect.Value) (res strictNumber, ok bool) {
+	ok = true
+	switch kind := GetKind(val); {
+	case IsKindInt(kind):
+		intVal := val.Int()
+		res.negative = intVal < 0
+		res.beyondExact = intVal > maxExactFloat || intVal < -maxExactFloat
+	case IsKindUint(kind):
+		res.beyondExact = val.Uint() > maxExactFloat
+	case IsKindFloat(kind):
+		// The float is always exact in float64, so only its sign and its fractional part are checked.
+		floatVal := val.Float()
+		res.negative = floatVal < 0
+		res.fractional = floatVal != math.Trunc(floatVal)
+	case IsKindString(kind), isValueBytes(val):
+		var str string
+		if IsKindString(kind) {
+			str = val.String()
+		} else {
+			str = string(val.Bytes())
+		}
+		number, err := decimal.NewFromString(strings.TrimSpace(str))
+		if err != nil {
+			ok = false
+			return
+		}
+		res.negative = number.Sign() < 0
+		res.fractional = !number.Equal(number.Truncate(0))
+		res.beyondExact = !res.fractional && number.Abs().GreaterThan(decimal.NewFromInt(maxExactFloat))
+	default:
+		ok = false
+	}
+	return
+}
+
+// getStrictViolation returns the bitmask of all policies violated by converting val to the dstType,
+// it returns StrictNone if there is none.
+func getStrictViolation(val reflect.Value, dstType reflect.Type) (res StrictPolicy) {
+	srcKind, dstKind := GetKind(val), dstType.Kind()
+	isSrcString := IsKindString(srcKind) || isValueBytes(val)
+	switch {
+	case isKindStrictNumber(dstKind) && isSrcString:
+		res |= StrictStringToNumber
+	case (IsKindBool(srcKind) && isKindStrictNumber(dstKind)) || (isKindStrictNumber(srcKind) && IsKindBool(dstKind)):
+		res |= StrictBoolNumber
+		return
+	}
+	if !isKindStrictNumber(dstKind) || !(isKindNumber(srcKind) || isSrcString) {
+		return
+	}
+
+	number, ok := getStrictNumber(val)
+	if !ok {
+		return
+	}
+	if (IsKindInt(dstKind) || IsKindUint(dstKind)) && number.fractional {
+		res |= StrictFractionalToInt
+	}
+	if IsKindUint(dstKind) && number.negative {
+		res |= StrictNegativeToUint
+	}
+	if (IsKindFloat(dstKind) || IsKindComplex(dstKind)) && number.beyondExact {
+		res |= StrictPrecisionLoss
+	}
+	return
+}
+
+// checkStrict checks whether converting val to the dstType violates the policy set by WithStrictMode.
+func checkStrict(val reflect.Value, dstType reflect.Type, option *Option) (err error) {
+	if option.StrictMode == StrictNone {
+		return
+	}
+
+	val = GetChildElem(val)
+	if !val.IsValid() {
+		return
+	}
+	policy := getStrictViolation(val, dstType) & option.StrictMode
+	if policy != StrictNone {
+		err = getErrStrict(val, dstType, policy)
+	}
+	return
+}
@@ -0,0 +1,246 @@
+package reflecthelper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		extract    func(val reflect.Value, fnOpts ...FuncOption) error
+		input      interface{}
+		policy     StrictPolicy
+		wantPolicy StrictPolicy
+	}{
+		{
+			name:       "string to int",
+			extract:    extractIntFn,
+			input:      "12",
+			policy:     StrictAll,
+			wantPolicy: StrictStringToNumber,
+		},
+		{
+			name:       "bytes to float",
+			extract:    extractFloatFn,
+			input:      []byte("1.5"),
+			policy:     StrictStringToNumber,
+			wantPolicy: StrictStringToNumber,
+		},
+		{
+			name:       "string pointer to uint",
+			extract:    extractUintFn,
+			input:      func() *string { str := "12"; return &str }(),
+			policy:     StrictAll,
+			wantPolicy: StrictStringToNumber,
+		},
+		{
+			name:       "bool to int",
+			extract:    extractIntFn,
+			input:      true,
+			policy:     StrictAll,
+			wantPolicy: StrictBoolNumber,
+		},
+		{
+			name:       "int to bool",
+			extract:    extractBoolFn,
+			input:      1,
+			policy:     StrictBoolNumber,
+			wantPolicy: StrictBoolNumber,
+		},
+		{
+			name:       "fractional float to int",
+			extract:    extractIntFn,
+			input:      2.5,
+			policy:     StrictAll,
+			wantPolicy: StrictFractionalToInt,
+		},
+		{
+			name:    "whole float to int",
+			extract: extractIntFn,
+			input:   2.0,
+			policy:  StrictAll,
+		},
+		{
+			name:       "negative int to uint",
+			extract:    extractUintFn,
+			input:      -1,
+			policy:     StrictAll,
+			wantPolicy: StrictNegativeToUint,
+		},
+		{
+			name:       "negative float to uint",
+			extract:    extractUintFn,
+			input:      -2.0,
+			policy:     StrictNegativeToUint,
+			wantPolicy: StrictNegativeToUint,
+		},
+		{
+			name:       "large int64 to float",
+			extract:    extractFloatFn,
+			input:      int64(1<<53 + 1),
+			policy:     StrictAll,
+			wantPolicy: StrictPrecisionLoss,
+		},
+		{
+			name:    "exact int64 to float",
+			extract: extractFloatFn,
+			input:   int64(1 << 53),
+			policy:  StrictAll,
+		},
+		{
+			name:       "large uint64 to complex",
+			extract:    extractComplexFn,
+			input:      uint64(1<<63 + 1),
+			policy:     StrictPrecisionLoss,
+			wantPolicy: StrictPrecisionLoss,
+		},
+		{
+			name:       "negative fractional float to uint with only negative policy",
+			extract:    extractUintFn,
+			input:      -2.5,
+			policy:     StrictNegativeToUint,
+			wantPolicy: StrictNegativeToUint,
+		},
+		{
+			name:       "negative fractional float to uint with only fractional policy",
+			extract:    extractUintFn,
+			input:      -2.5,
+			policy:     StrictFractionalToInt,
+			wantPolicy: StrictFractionalToInt,
+		},
+		{
+			name:       "negative fractional float to uint with all policies",
+			extract:    extractUintFn,
+			input:      -2.5,
+			policy:     StrictAll,
+			wantPolicy: StrictFractionalToInt | StrictNegativeToUint,
+		},
+		{
+			name:       "negative string to uint with only negative policy",
+			extract:    extractUintFn,
+			input:      "-5",
+			policy:     StrictNegativeToUint,
+			wantPolicy: StrictNegativeToUint,
+		},
+		{
+			name:       "fractional string to int with only fractional policy",
+			extract:    extractIntFn,
+			input:      "2.5",
+			policy:     StrictFractionalToInt,
+			wantPolicy: StrictFractionalToInt,
+		},
+		{
+			name:       "large string to float with only precision policy",
+			extract:    extractFloatFn,
+			input:      []byte("9007199254740993"),
+			policy:     StrictPrecisionLoss,
+			wantPolicy: StrictPrecisionLoss,
+		},
+		{
+			name:       "negative string to uint with all policies",
+			extract:    extractUintFn,
+			input:      "-5",
+			policy:     StrictAll,
+			wantPolicy: StrictStringToNumber | StrictNegativeToUint,
+		},
+		{
+			name:    "whole string to uint with number policies",
+			extract: extractUintFn,
+			input:   "5",
+			policy:  StrictFractionalToInt | StrictNegativeToUint | StrictPrecisionLoss,
+		},
+		{
+			name:    "policy not selected",
+			extract: extractIntFn,
+			input:   "12",
+			policy:  StrictBoolNumber,
+		},
+		{
+			name:    "strict mode disabled",
+			extract: extractIntFn,
+			input:   true,
+			policy:  StrictNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.extract(reflect.ValueOf(tt.input), WithStrictMode(tt.policy))
+			if tt.wantPolicy == StrictNone {
+				assert.Nil(t, err)
+				return
+			}
+			var strictErr *StrictError
+			assert.True(t, errors.As(err, &strictErr))
+			assert.Equal(t, tt.wantPolicy, strictErr.Policy)
+		})
+	}
+}
+
+func extractIntFn(val reflect.Value, fnOpts ...FuncOption) (err error) {
+	_, err = ExtractInt(val, fnOpts...)
+	return
+}
+
+func extractUintFn(val reflect.Value, fnOpts ...FuncOption) (err error) {
+	_, err = ExtractUint(val, fnOpts...)
+	return
+}
+
+func extractFloatFn(val reflect.Value, fnOpts ...FuncOption) (err error) {
+	_, err = ExtractFloat(val, fnOpts...)
+	return
+}
+
+func extractComplexFn(val reflect.Value, fnOpts ...FuncOption) (err error) {
+	_, err = ExtractComplex(val, fnOpts...)
+	return
+}
+
+func extractBoolFn(val reflect.Value, fnOpts ...FuncOption) (err error) {
+	_, err = ExtractBool(val, fnOpts...)
+	return
+}
+
+func TestStrictModeAssign(t *testing.T) {
+	type target struct {
+		Count  int16
+		Ratio  float32
+		Active bool
+	}
+	var res target
+	err := AssignReflect(reflect.ValueOf(&res.Count), reflect.ValueOf("12"), WithStrictMode(StrictAll))
+	var strictErr *StrictError
+	assert.True(t, errors.As(err, &strictErr))
+	assert.Equal(t, "error strict mode disallows string to number conversion, kind: string type: string val: 12 dst type: int64", err.Error())
+	assert.Equal(t, int16(0), res.Count)
+
+	err = AssignReflect(reflect.ValueOf(&res.Count), reflect.ValueOf(int8(12)), WithStrictMode(StrictAll))
+	assert.Nil(t, err)
+	assert.Equal(t, int16(12), res.Count)
+
+	err = AssignReflect(reflect.ValueOf(&res.Active), reflect.ValueOf(1.0), WithStrictMode(StrictAll))
+	assert.True(t, errors.As(err, &strictErr))
+
+	var size uint64
+	err = AssignReflect(reflect.ValueOf(&size), reflect.ValueOf(-2.5), WithStrictMode(StrictNegativeToUint))
+	assert.True(t, errors.As(err, &strictErr))
+	assert.Equal(t, StrictNegativeToUint, strictErr.Policy)
+	err = AssignReflect(reflect.ValueOf(&size), reflect.ValueOf("-5"), WithStrictMode(StrictNegativeToUint))
+	assert.True(t, errors.As(err, &strictErr))
+	assert.Equal(t, uint64(0), size)
+
+	err = AssignReflect(reflect.ValueOf(&res), reflect.ValueOf(map[string]interface{}{"Count": 1}), WithStrictMode(StrictAll))
+	assert.Nil(t, err)
+
+	var list []int
+	err = AssignReflect(reflect.ValueOf(&list), reflect.ValueOf([]interface{}{1, "2"}), WithStrictMode(StrictAll))
+	assert.True(t, errors.As(err, &strictErr))
+	assert.Equal(t, "[1]", strictErr.Path)
+
+	assert.Equal(t, "string to number|negative to unsigned integer", (StrictStringToNumber | StrictNegativeToUint).String())
+	assert.Equal(t, "none", StrictNone.String())
+}
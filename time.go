@@ -1,6 +1,40 @@
 package reflecthelper
 
-import "time"
+import (
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// UnixUnit is the unit of the Unix timestamp.
+type UnixUnit uint8
+
+// List of all UnixUnit.
+const (
+	// UnixAuto detects the unit of the Unix timestamp from its magnitude.
+	// The time.Time is converted to the Unix timestamp in seconds.
+	UnixAuto UnixUnit = iota
+	UnixSecond
+	UnixMilli
+	UnixMicro
+	UnixNano
+)
+
+// perSecond returns the number of the unit in a second.
+func (u UnixUnit) perSecond() int64 {
+	switch u {
+	case UnixMilli:
+		return int64(time.Second / time.Millisecond)
+	case UnixMicro:
+		return int64(time.Second / time.Microsecond)
+	case UnixNano:
+		return int64(time.Second)
+	}
+	return 1
+}
 
 var (
 	listLayouts = []string{
@@ -22,13 +56,142 @@ var (
 	}
 )
 
+func loadLocation(name string) (loc *time.Location, err error) {
+	loc, err = time.LoadLocation(strings.TrimSpace(name))
+	return
+}
+
+func getTimeLocation(option *Option) (loc *time.Location) {
+	loc = option.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return
+}
+
+func normalizeTime(input time.Time, option *Option) (result time.Time) {
+	result = input
+	if option.NormalizeTimeUTC {
+		result = result.UTC()
+	}
+	return
+}
+
+// detectUnixUnit detects the unit of the timestamp from its magnitude,
+// e.g. 1e11 seconds is beyond the year 5000, so the larger timestamp is in milliseconds.
+func detectUnixUnit(timestamp decimal.Decimal) UnixUnit {
+	abs := timestamp.Abs()
+	switch {
+	case abs.LessThan(decimal.New(1, 11)):
+		return UnixSecond
+	case abs.LessThan(decimal.New(1, 14)):
+		return UnixMilli
+	case abs.LessThan(decimal.New(1, 17)):
+		return UnixMicro
+	}
+	return UnixNano
+}
+
+func isNumericString(input string) bool {
+	_, err := decimal.NewFromString(strings.TrimSpace(input))
+	return err == nil
+}
+
+// parseUnixTime converts the number or numeric string of val as the Unix timestamp to the time.Time.
+// The fraction of the timestamp is kept up to the nanosecond.
+func parseUnixTime(val reflect.Value, option *Option) (result time.Time, err error) {
+	timestamp, err := toDecimal(val, option)
+	if err != nil {
+		err = getErrParse(val, TypeTime, err)
+		return
+	}
+
+	unit := option.UnixUnit
+	if unit == UnixAuto {
+		unit = detectUnixUnit(timestamp)
+	}
+	seconds := timestamp.Div(decimal.New(unit.perSecond(), 0))
+	sec := seconds.Floor()
+	if sec.LessThan(decimal.New(math.MinInt64, 0)) || sec.GreaterThan(decimal.New(math.MaxInt64, 0)) {
+		err = getErrOverflowExtract(val, TypeTime)
+		return
+	}
+
+	nsec := seconds.Sub(sec).Shift(9).Round(0)
+	result = normalizeTime(time.Unix(sec.IntPart(), nsec.IntPart()).In(getTimeLocation(option)), option)
+	return
+}
+
+// formatUnixTime converts the input to the Unix timestamp in the unit of the option.
+// The timestamp is computed from the seconds instead of UnixNano, because UnixNano is undefined
+// outside the years 1678 and 2262, and returns *OverflowError if it doesn't fit in int64.
+func formatUnixTime(input time.Time, option *Option) (result int64, err error) {
+	perSecond := option.UnixUnit.perSecond()
+	seconds := input.Unix()
+	fraction := int64(input.Nanosecond()) / (int64(time.Second) / perSecond)
+	if seconds > (math.MaxInt64-fraction)/perSecond || seconds < math.MinInt64/perSecond {
+		err = getErrOverflowExtract(reflect.ValueOf(input), TypeInt64)
+		return
+	}
+
+	result = seconds*perSecond + fraction
+	return
+}
+
 func parseTime(timeTxt string, option *Option) (result time.Time, err error) {
-	layouts := append(option.TimeLayouts, listLayouts...)
-	for _, layout := range layouts {
-		result, err = time.Parse(layout, timeTxt)
+	result, _, err = parseTimeLayout(timeTxt, option)
+	return
+}
+
+// parseTimeLayout parses the timeTxt using the output layout, the user layouts, and listLayouts,
+// and then infers the layout if the time inference is enabled.
+// It returns the matched layout along with the result, the layout is empty for the relative time.
+func parseTimeLayout(timeTxt string, option *Option) (result time.Time, layout string, err error) {
+	defer func() {
 		if err == nil {
-			break
+			result = normalizeTime(result, option)
+		}
+	}()
+
+	if option.RelativeTime {
+		var ok bool
+		result, ok, err = parseRelativeTime(timeTxt, option)
+		if ok {
+			return
 		}
 	}
+
+	loc := getTimeLocation(option)
+	layouts := make([]string, 0, len(option.TimeLayouts)+len(listLayouts)+1)
+	// The output layout is tried first so that the formatted time.Time can be parsed back.
+	if option.TimeOutputLayout != "" {
+		layouts = append(layouts, option.TimeOutputLayout)
+	}
+	layouts = append(layouts, option.TimeLayouts...)
+	layouts = append(layouts, listLayouts...)
+	for _, layout = range layouts {
+		result, err = time.ParseInLocation(layout, timeTxt, loc)
+		if err == nil {
+			return
+		}
+	}
+	layout = ""
+	if !option.TimeInference {
+		return
+	}
+
+	if weekResult, ok := parseISOWeek(timeTxt, loc); ok {
+		result, layout, err = weekResult, LayoutISOWeek, nil
+		return
+	}
+	inferredLayout, err := inferTimeLayout(timeTxt, option.DayFirst)
+	if err != nil {
+		return
+	}
+	result, err = time.ParseInLocation(inferredLayout, strings.TrimSpace(timeTxt), loc)
+	if err != nil {
+		return
+	}
+	layout = inferredLayout
 	return
 }
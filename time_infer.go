@@ -0,0 +1,365 @@
+package reflecthelper
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// LayoutISOWeek is the layout reported for the ISO 8601 week dates, e.g. 2021-W05-3 or 2021W05.
+// It is not a valid layout for time.Format.
+const LayoutISOWeek = "2006-Www-D"
+
+type timeTokenKind uint8
+
+const (
+	timeTokenDigit timeTokenKind = iota + 1
+	timeTokenAlpha
+	timeTokenOther
+)
+
+type timeToken struct {
+	kind timeTokenKind
+	text string
+}
+
+var (
+	timeMonthNames = map[string]string{
+		"january": "January", "february": "January", "march": "January", "april": "January",
+		"may": "Jan", "june": "January", "july": "January", "august": "January",
+		"september": "January", "october": "January", "november": "January", "december": "January",
+		"jan": "Jan", "feb": "Jan", "mar": "Jan", "apr": "Jan", "jun": "Jan", "jul": "Jan",
+		"aug": "Jan", "sep": "Jan", "oct": "Jan", "nov": "Jan", "dec": "Jan",
+	}
+	timeWeekdayNames = map[string]string{
+		"monday": "Monday", "tuesday": "Monday", "wednesday": "Monday", "thursday": "Monday",
+		"friday": "Monday", "saturday": "Monday", "sunday": "Monday",
+		"mon": "Mon", "tue": "Mon", "wed": "Mon", "thu": "Mon", "fri": "Mon", "sat": "Mon", "sun": "Mon",
+	}
+)
+
+func tokenizeTime(input string) (res []timeToken) {
+	for index := 0; index < len(input); {
+		char := rune(input[index])
+		var kind timeTokenKind
+		switch {
+		case unicode.IsDigit(char):
+			kind = timeTokenDigit
+		case unicode.IsLetter(char):
+			kind = timeTokenAlpha
+		default:
+			res = append(res, timeToken{kind: timeTokenOther, text: input[index : index+1]})
+			index++
+			continue
+		}
+
+		start := index
+		for index < len(input) && getTimeTokenKind(rune(input[index])) == kind {
+			index++
+		}
+		res = append(res, timeToken{kind: kind, text: input[start:index]})
+	}
+	return
+}
+
+func getTimeTokenKind(char rune) timeTokenKind {
+	switch {
+	case unicode.IsDigit(char):
+		return timeTokenDigit
+	case unicode.IsLetter(char):
+		return timeTokenAlpha
+	}
+	return timeTokenOther
+}
+
+// timeInferrer builds the layout from the tokens of the time string.
+type timeInferrer struct {
+	tokens    []timeToken
+	layouts   []string
+	dateNums  []int
+	hasMonth  bool
+	is12Hour  bool
+	timeIndex int
+	dayFirst  bool
+}
+
+// inferTimeLayout infers the layout of the input by classifying its tokens as date, time, and zone elements.
+func inferTimeLayout(input string, dayFirst bool) (layout string, err error) {
+	inferrer := &timeInferrer{
+		tokens:   tokenizeTime(strings.TrimSpace(input)),
+		dayFirst: dayFirst,
+	}
+	layout, err = inferrer.infer(input)
+	return
+}
+
+func (t *timeInferrer) infer(input string) (layout string, err error) {
+	t.layouts = make([]string, len(t.tokens))
+	for _, token := range t.tokens {
+		lower := strings.ToLower(token.text)
+		if token.kind != timeTokenAlpha {
+			continue
+		}
+		if _, ok := timeMonthNames[lower]; ok {
+			t.hasMonth = true
+		}
+		if lower == "am" || lower == "pm" {
+			t.is12Hour = true
+		}
+	}
+
+	for index := 0; index < len(t.tokens); index++ {
+		switch t.tokens[index].kind {
+		case timeTokenDigit:
+			index, err = t.inferDigit(index)
+		case timeTokenAlpha:
+			t.inferAlpha(index)
+		default:
+			t.layouts[index] = t.tokens[index].text
+		}
+		if err != nil {
+			err = getErrInferTimeLayout(input)
+			return
+		}
+	}
+
+	err = t.inferDate()
+	if err != nil {
+		err = getErrInferTimeLayout(input)
+		return
+	}
+	layout = strings.Join(t.layouts, "")
+	return
+}
+
+func (t *timeInferrer) isToken(index int, text string) bool {
+	return index >= 0 && index < len(t.tokens) && t.tokens[index].text == text
+}
+
+func (t *timeInferrer) inferDigit(index int) (next int, err error) {
+	next = index
+	text := t.tokens[index].text
+	switch {
+	case t.timeIndex == 0 && t.isToken(index+1, ":"):
+		t.timeIndex = 1
+		t.layouts[index] = t.getHourLayout(text)
+	case t.timeIndex > 0 && t.timeIndex < 3 && t.isToken(index-1, ":"):
+		t.timeIndex++
+		t.layouts[index] = getPaddedLayout(text, "4", "04")
+		if t.timeIndex == 3 {
+			t.layouts[index] = getPaddedLayout(text, "5", "05")
+		}
+	case t.timeIndex == 3 && (t.isToken(index-1, ".") || t.isToken(index-1, ",")):
+		t.layouts[index] = strings.Repeat("0", len(text))
+	case t.timeIndex > 0 && (t.isToken(index-1, "+") || t.isToken(index-1, "-")):
+		next, err = t.inferOffset(index)
+	case t.timeIndex == 0 && len(text) == 6 && t.isToken(index-1, "T"):
+		t.timeIndex = 3
+		t.layouts[index] = "150405"
+	default:
+		if t.timeIndex > 0 {
+			err = getErrInferTimeLayout(text)
+			return
+		}
+		t.dateNums = append(t.dateNums, index)
+	}
+	return
+}
+
+func (t *timeInferrer) getHourLayout(text string) string {
+	if t.is12Hour {
+		return getPaddedLayout(text, "3", "03")
+	}
+	return "15"
+}
+
+func getPaddedLayout(text string, layout string, paddedLayout string) string {
+	if len(text) == 2 {
+		return paddedLayout
+	}
+	return layout
+}
+
+// inferOffset infers the zone offset like +0700, +07:00, or +07.
+func (t *timeInferrer) inferOffset(index int) (next int, err error) {
+	next = index
+	sign := index - 1
+	text := t.tokens[index].text
+	switch {
+	case len(text) == 4:
+		t.layouts[sign], t.layouts[index] = "-", "0700"
+	case len(text) == 2 && t.isToken(index+1, ":") && index+2 < len(t.tokens) && len(t.tokens[index+2].text) == 2:
+		t.layouts[sign], t.layouts[index], t.layouts[index+1], t.layouts[index+2] = "-", "07", ":", "00"
+		next = index + 2
+	case len(text) == 2:
+		t.layouts[sign], t.layouts[index] = "-", "07"
+	default:
+		err = getErrInferTimeLayout(text)
+	}
+	return
+}
+
+func (t *timeInferrer) inferAlpha(index int) {
+	text := t.tokens[index].text
+	lower := strings.ToLower(text)
+	if layout, ok := timeMonthNames[lower]; ok {
+		t.layouts[index] = layout
+		return
+	}
+	if layout, ok := timeWeekdayNames[lower]; ok {
+		t.layouts[index] = layout
+		return
+	}
+
+	switch {
+	case lower == "am" || lower == "pm":
+		t.layouts[index] = "PM"
+		if text == lower {
+			t.layouts[index] = "pm"
+		}
+	case text == "Z" && t.timeIndex > 0:
+		t.layouts[index] = "Z07:00"
+	case t.timeIndex > 0 && len(text) >= 3 && len(text) <= 5 && strings.ToUpper(text) == text:
+		t.layouts[index] = "MST"
+	default:
+		// The literal text, e.g. the T separator or the ordinal suffix like st, nd, rd, and th.
+		t.layouts[index] = text
+	}
+}
+
+// inferDate assigns the year, month, and day layouts to the date numbers.
+func (t *timeInferrer) inferDate() (err error) {
+	texts := make([]string, len(t.dateNums))
+	for index, tokenIndex := range t.dateNums {
+		texts[index] = t.tokens[tokenIndex].text
+	}
+
+	var layouts []string
+	switch {
+	case t.hasMonth:
+		layouts, err = inferDateWithMonthName(texts)
+	case len(texts) == 1 && len(texts[0]) == 8:
+		layouts = []string{"20060102"}
+	case len(texts) == 1 && len(texts[0]) == 14:
+		layouts = []string{"20060102150405"}
+	case len(texts) == 1 && len(texts[0]) == 4:
+		layouts = []string{"2006"}
+	case len(texts) == 2 && len(texts[0]) == 4:
+		layouts = []string{"2006", getPaddedLayout(texts[1], "1", "01")}
+	case len(texts) == 2 && len(texts[1]) == 4:
+		layouts = []string{getPaddedLayout(texts[0], "1", "01"), "2006"}
+	case len(texts) == 3:
+		layouts, err = t.inferNumericDate(texts)
+	case len(texts) == 0 && t.timeIndex > 0:
+	default:
+		err = getErrInferTimeLayout(strings.Join(texts, " "))
+	}
+	if err != nil {
+		return
+	}
+
+	for index, tokenIndex := range t.dateNums {
+		t.layouts[tokenIndex] = layouts[index]
+	}
+	return
+}
+
+func inferDateWithMonthName(texts []string) (layouts []string, err error) {
+	var hasDay, hasYear bool
+	for _, text := range texts {
+		switch {
+		case len(text) == 4 && !hasYear:
+			hasYear = true
+			layouts = append(layouts, "2006")
+		case len(text) <= 2 && !hasDay:
+			hasDay = true
+			layouts = append(layouts, getPaddedLayout(text, "2", "02"))
+		case len(text) == 2 && !hasYear:
+			hasYear = true
+			layouts = append(layouts, "06")
+		default:
+			err = getErrInferTimeLayout(text)
+			return
+		}
+	}
+	return
+}
+
+func (t *timeInferrer) inferNumericDate(texts []string) (layouts []string, err error) {
+	for _, text := range texts {
+		if len(text) > 4 {
+			err = getErrInferTimeLayout(text)
+			return
+		}
+	}
+
+	if len(texts[0]) == 4 {
+		layouts = []string{"2006", getPaddedLayout(texts[1], "1", "01"), getPaddedLayout(texts[2], "2", "02")}
+		return
+	}
+
+	yearLayout := "2006"
+	if len(texts[2]) <= 2 {
+		yearLayout = "06"
+	}
+	first, _ := strconv.Atoi(texts[0])
+	second, _ := strconv.Atoi(texts[1])
+	dayFirst := t.dayFirst
+	switch {
+	case first > 12:
+		dayFirst = true
+	case second > 12:
+		dayFirst = false
+	}
+	if dayFirst {
+		layouts = []string{getPaddedLayout(texts[0], "2", "02"), getPaddedLayout(texts[1], "1", "01"), yearLayout}
+		return
+	}
+	layouts = []string{getPaddedLayout(texts[0], "1", "01"), getPaddedLayout(texts[1], "2", "02"), yearLayout}
+	return
+}
+
+// parseISOWeek parses the ISO 8601 week date, e.g. 2021-W05, 2021-W05-3, 2021W05, or 2021W053.
+func parseISOWeek(input string, loc *time.Location) (res time.Time, ok bool) {
+	input = strings.TrimSpace(input)
+	if len(input) < 7 {
+		return
+	}
+
+	year, err := strconv.Atoi(input[:4])
+	if err != nil {
+		return
+	}
+	rest := strings.TrimPrefix(input[4:], "-")
+	if len(rest) < 3 || (rest[0] != 'W' && rest[0] != 'w') {
+		return
+	}
+	week, err := strconv.Atoi(rest[1:3])
+	if err != nil {
+		return
+	}
+
+	day := 1
+	rest = strings.TrimPrefix(rest[3:], "-")
+	switch {
+	case len(rest) == 1:
+		day, err = strconv.Atoi(rest)
+		if err != nil {
+			return
+		}
+	case len(rest) > 1:
+		return
+	}
+	if week < 1 || week > 53 || day < 1 || day > 7 {
+		return
+	}
+
+	// The 4th of January is always in the first week.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	res = monday.AddDate(0, 0, (week-1)*7+day-1)
+	resYear, resWeek := res.ISOWeek()
+	ok = resYear == year && resWeek == week
+	return
+}
@@ -0,0 +1,157 @@
+package reflecthelper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeLayout(t *testing.T) {
+	tests := []struct {
+		name       string
+		timeTxt    string
+		fnOpts     []FuncOption
+		wantTime   time.Time
+		wantLayout string
+		wantErr    bool
+	}{
+		{
+			name:       "known layout without inference",
+			timeTxt:    "2021-03-04T05:06:07Z",
+			wantTime:   time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+			wantLayout: time.RFC3339,
+		},
+		{
+			name:    "unknown layout without inference",
+			timeTxt: "2021-03-04",
+			wantErr: true,
+		},
+		{
+			name:       "date only",
+			timeTxt:    "2021-03-04",
+			fnOpts:     []FuncOption{WithTimeInference(true)},
+			wantTime:   time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC),
+			wantLayout: "2006-01-02",
+		},
+		{
+			name:       "date and time",
+			timeTxt:    "2021-03-04 05:06:07",
+			fnOpts:     []FuncOption{WithTimeInference(true)},
+			wantTime:   time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+			wantLayout: "2006-01-02 15:04:05",
+		},
+		{
+			name:       "date and time with fraction and offset",
+			timeTxt:    "2021-03-04 05:06:07.123 +07:00",
+			fnOpts:     []FuncOption{WithTimeInference(true)},
+			wantTime:   time.Date(2021, 3, 4, 5, 6, 7, 123000000, time.FixedZone("", 7*3600)),
+			wantLayout: "2006-01-02 15:04:05.000 -07:00",
+		},
+		{
+			name:       "month-first by default",
+			timeTxt:    "01/02/2006",
+			fnOpts:     []FuncOption{WithTimeInference(true)},
+			wantTime:   time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC),
+			wantLayout: "01/02/2006",
+		},
+		{
+			name:       "day-first",
+			timeTxt:    "01/02/2006",
+			fnOpts:     []FuncOption{WithTimeInference(true), WithDayFirst(true)},
+			wantTime:   time.Date(2006, 2, 1, 0, 0, 0, 0, time.UTC),
+			wantLayout: "02/01/2006",
+		},
+		{
+			name:       "day greater than 12 forces day-first",
+			timeTxt:    "19/09/2012 07:56",
+			fnOpts:     []FuncOption{WithTimeInference(true)},
+			wantTime:   time.Date(2012, 9, 19, 7, 56, 0, 0, time.UTC),
+			wantLayout: "02/01/2006 15:04",
+		},
+		{
+			name:       "day greater than 12 forces month-first",
+			timeTxt:    "09/19/2012",
+			fnOpts:     []FuncOption{WithTimeInference(true), WithDayFirst(true)},
+			wantTime:   time.Date(2012, 9, 19, 0, 0, 0, 0, time.UTC),
+			wantLayout: "01/02/2006",
+		},
+		{
+			name:       "compact date",
+			timeTxt:    "20060102",
+			fnOpts:     []FuncOption{WithTimeInference(true)},
+			wantTime:   time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC),
+			wantLayout: "20060102",
+		},
+		{
+			name:       "month name with 12-hour clock",
+			timeTxt:    "March 4, 2021 5:06 PM",
+			fnOpts:     []FuncOption{WithTimeInference(true)},
+			wantTime:   time.Date(2021, 3, 4, 17, 6, 0, 0, time.UTC),
+			wantLayout: "January 2, 2006 3:04 PM",
+		},
+		{
+			name:       "iso week with weekday",
+			timeTxt:    "2021-W05-3",
+			fnOpts:     []FuncOption{WithTimeInference(true)},
+			wantTime:   time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC),
+			wantLayout: LayoutISOWeek,
+		},
+		{
+			name:       "compact iso week",
+			timeTxt:    "2021W01",
+			fnOpts:     []FuncOption{WithTimeInference(true)},
+			wantTime:   time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+			wantLayout: LayoutISOWeek,
+		},
+		{
+			name:    "invalid iso week",
+			timeTxt: "2021-W53",
+			fnOpts:  []FuncOption{WithTimeInference(true)},
+			wantErr: true,
+		},
+		{
+			name:    "not a time",
+			timeTxt: "hello world",
+			fnOpts:  []FuncOption{WithTimeInference(true)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTime, gotLayout, err := ParseTimeLayout(tt.timeTxt, tt.fnOpts...)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				assert.Empty(t, gotLayout)
+				return
+			}
+			assert.Nil(t, err)
+			assert.True(t, tt.wantTime.Equal(gotTime), "got %s", gotTime)
+			assert.Equal(t, tt.wantLayout, gotLayout)
+		})
+	}
+}
+
+func TestInferTimeLayout(t *testing.T) {
+	layout, err := InferTimeLayout("2021-03-04T05:06:07Z")
+	assert.Nil(t, err)
+	assert.Equal(t, "2006-01-02T15:04:05Z07:00", layout)
+
+	layout, err = InferTimeLayout("04.03.2021", WithDayFirst(true))
+	assert.Nil(t, err)
+	assert.Equal(t, "02.01.2006", layout)
+
+	layout, err = InferTimeLayout("13/13/2021")
+	assert.NotNil(t, err)
+	assert.Empty(t, layout)
+}
+
+func TestExtractTimeInference(t *testing.T) {
+	var target struct {
+		Time time.Time
+	}
+	err := AssignReflect(reflect.ValueOf(&target.Time), reflect.ValueOf("20210304"), WithTimeInference(true))
+	assert.Nil(t, err)
+	assert.True(t, time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC).Equal(target.Time))
+}
@@ -1,10 +1,41 @@
 package reflecthelper
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // ParseTime parses the timeTxt string to the time.Time using various formats.
+// The layout is inferred from the timeTxt if WithTimeInference is used.
+// The timeTxt without the zone information is parsed in the location set by WithLocation.
+// The relative time expressions like now-15m are parsed if WithRelativeTime is used.
 func ParseTime(timeTxt string, fnOpts ...FuncOption) (result time.Time, err error) {
 	opt := NewOption().Assign(fnOpts...)
 	result, err = parseTime(timeTxt, opt)
 	return
 }
+
+// ParseTimeLayout is like ParseTime but it also returns the matched layout.
+// The layout is LayoutISOWeek if the timeTxt is an ISO 8601 week date.
+func ParseTimeLayout(timeTxt string, fnOpts ...FuncOption) (result time.Time, layout string, err error) {
+	opt := NewOption().Assign(fnOpts...)
+	result, layout, err = parseTimeLayout(timeTxt, opt)
+	return
+}
+
+// InferTimeLayout infers the layout of the timeTxt, e.g. 2006-01-02 15:04:05 for 2021-03-04 05:06:07.
+// The ambiguous numeric date like 01/02/2006 is month-first unless WithDayFirst is used.
+// InferTimeLayout doesn't detect the ISO 8601 week dates.
+func InferTimeLayout(timeTxt string, fnOpts ...FuncOption) (layout string, err error) {
+	opt := NewOption().Assign(fnOpts...)
+	layout, err = inferTimeLayout(timeTxt, opt.DayFirst)
+	if err != nil {
+		return
+	}
+
+	_, err = time.Parse(layout, strings.TrimSpace(timeTxt))
+	if err != nil {
+		layout = ""
+	}
+	return
+}
@@ -0,0 +1,115 @@
+package reflecthelper
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FuncClock is a function to get the current time, it is used to evaluate the relative time expressions.
+type FuncClock func() time.Time
+
+type relativeTimeBase func(now time.Time) time.Time
+
+var (
+	relativeTimeBases = map[string]relativeTimeBase{
+		"now":          func(now time.Time) time.Time { return now },
+		"today":        startOfDay,
+		"yesterday":    func(now time.Time) time.Time { return startOfDay(now).AddDate(0, 0, -1) },
+		"tomorrow":     func(now time.Time) time.Time { return startOfDay(now).AddDate(0, 0, 1) },
+		"start of day": startOfDay,
+		"start of week": func(now time.Time) time.Time {
+			// The week starts on Monday as in ISO 8601.
+			return startOfDay(now).AddDate(0, 0, -((int(now.Weekday()) + 6) % 7))
+		},
+		"start of month": func(now time.Time) time.Time {
+			return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		},
+		"start of year": func(now time.Time) time.Time {
+			return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+		},
+	}
+
+	// relativeTimeBaseRegex matches the base of the relative time, e.g. now, today, or start of month.
+	relativeTimeBaseRegex = regexp.MustCompile(`^(now|today|yesterday|tomorrow|start\s+of\s+(?:day|week|month|year))\b`)
+	// relativeTimeOffsetRegex matches the signed offset of the relative time, e.g. -15m or + 1 day.
+	relativeTimeOffsetRegex = regexp.MustCompile(`^\s*([+-])\s*([^+-]+)`)
+	spaceRegex              = regexp.MustCompile(`\s+`)
+)
+
+func startOfDay(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+func getNow(option *Option) (now time.Time) {
+	if option.Clock != nil {
+		now = option.Clock()
+	} else {
+		now = time.Now()
+	}
+	now = now.In(getTimeLocation(option))
+	return
+}
+
+// parseRelativeTime parses the relative time expressions, e.g. now, now-15m, today, yesterday, +2h, or start of month.
+// The ok is false if the timeTxt is not a relative time expression.
+func parseRelativeTime(timeTxt string, option *Option) (result time.Time, ok bool, err error) {
+	input := strings.ToLower(strings.TrimSpace(timeTxt))
+	match := relativeTimeBaseRegex.FindString(input)
+	switch {
+	case match != "":
+		input = input[len(match):]
+	case (strings.HasPrefix(input, "+") || strings.HasPrefix(input, "-")) && !isNumericString(input):
+		match = "now"
+	default:
+		return
+	}
+
+	ok = true
+	result = relativeTimeBases[spaceRegex.ReplaceAllString(match, " ")](getNow(option))
+	for strings.TrimSpace(input) != "" {
+		offset := relativeTimeOffsetRegex.FindStringSubmatch(input)
+		if offset == nil {
+			err = getErrRelativeTime(timeTxt)
+			return
+		}
+
+		var (
+			days int
+			dur  time.Duration
+		)
+		days, dur, err = parseRelativeOffset(offset[2], option)
+		if err != nil {
+			err = getErrRelativeTime(timeTxt)
+			return
+		}
+		if offset[1] == "-" {
+			days, dur = -days, -dur
+		}
+		result = result.AddDate(0, 0, days).Add(dur)
+		input = input[len(offset[0]):]
+	}
+	return
+}
+
+// parseRelativeOffset parses the offset into the calendar days and the remaining duration.
+// The days and weeks are added as the calendar days, so that the offset keeps the wall clock across DST changes.
+func parseRelativeOffset(offset string, option *Option) (days int, res time.Duration, err error) {
+	offset = strings.TrimSpace(offset)
+	res, err = time.ParseDuration(offset)
+	if err == nil {
+		return
+	}
+
+	builder := &durationBuilder{input: offset, calendarDays: true}
+	err = builder.parse(option)
+	if err != nil {
+		return
+	}
+	days, err = builder.resultDays()
+	if err != nil {
+		return
+	}
+	res, err = builder.result()
+	return
+}
@@ -0,0 +1,153 @@
+package reflecthelper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRelativeTime(t *testing.T) {
+	// Thursday.
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	opts := []FuncOption{
+		WithRelativeTime(true),
+		WithClock(func() time.Time { return now }),
+	}
+	tests := []struct {
+		name    string
+		timeTxt string
+		fnOpts  []FuncOption
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:    "now",
+			timeTxt: "now",
+			want:    now,
+		},
+		{
+			name:    "now minus minutes",
+			timeTxt: "now-15m",
+			want:    now.Add(-15 * time.Minute),
+		},
+		{
+			name:    "now with spaced offsets",
+			timeTxt: " Now - 1 day + 2h ",
+			want:    now.Add(-22 * time.Hour),
+		},
+		{
+			name:    "today",
+			timeTxt: "today",
+			want:    time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "yesterday",
+			timeTxt: "yesterday",
+			want:    time.Date(2021, 3, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "tomorrow with offset",
+			timeTxt: "tomorrow+9h",
+			want:    time.Date(2021, 3, 5, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "offset only",
+			timeTxt: "+2h",
+			want:    now.Add(2 * time.Hour),
+		},
+		{
+			name:    "start of week",
+			timeTxt: "start of week",
+			want:    time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "start of month",
+			timeTxt: "start of month",
+			want:    time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "start of year minus ISO duration",
+			timeTxt: "start of year - P1D",
+			want:    time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "today in location",
+			timeTxt: "today",
+			fnOpts:  []FuncOption{WithLocation(time.FixedZone("UTC-8", -8*3600))},
+			want:    time.Date(2021, 3, 3, 0, 0, 0, 0, time.FixedZone("UTC-8", -8*3600)),
+		},
+		{
+			name:    "invalid offset",
+			timeTxt: "now-15x",
+			wantErr: true,
+		},
+		{
+			name:    "trailing text",
+			timeTxt: "now later",
+			wantErr: true,
+		},
+		{
+			name:    "absolute time still works",
+			timeTxt: "2021-03-04T05:06:07Z",
+			want:    now,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTime(tt.timeTxt, append(opts, tt.fnOpts...)...)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s want %s", got, tt.want)
+		})
+	}
+}
+
+func TestParseRelativeTimeDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+
+	// The DST in New York starts on 2021-03-14.
+	now := time.Date(2021, 3, 13, 12, 0, 0, 0, newYork)
+	opts := []FuncOption{
+		WithRelativeTime(true),
+		WithClock(func() time.Time { return now }),
+		WithLocation(newYork),
+	}
+	tests := []struct {
+		timeTxt string
+		want    time.Time
+	}{
+		{timeTxt: "now+1d", want: time.Date(2021, 3, 14, 12, 0, 0, 0, newYork)},
+		{timeTxt: "now + 1 week", want: time.Date(2021, 3, 20, 12, 0, 0, 0, newYork)},
+		{timeTxt: "now+1d-1h", want: time.Date(2021, 3, 14, 11, 0, 0, 0, newYork)},
+		{timeTxt: "now+1.5d", want: now.Add(36 * time.Hour)},
+		{timeTxt: "now+24h", want: time.Date(2021, 3, 14, 13, 0, 0, 0, newYork)},
+		{timeTxt: "start of week + 13d", want: time.Date(2021, 3, 21, 0, 0, 0, 0, newYork)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.timeTxt, func(t *testing.T) {
+			got, err := ParseTime(tt.timeTxt, opts...)
+			assert.Nil(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s want %s", got, tt.want)
+		})
+	}
+}
+
+func TestExtractRelativeTime(t *testing.T) {
+	_, err := ParseTime("now")
+	assert.NotNil(t, err)
+
+	got, err := ExtractTime(reflect.ValueOf("now"), WithRelativeTime(true))
+	assert.Nil(t, err)
+	assert.WithinDuration(t, time.Now(), got, time.Minute)
+	assert.Equal(t, time.UTC, got.Location())
+
+	got, err = ExtractTime(reflect.ValueOf("-1614834367"), WithRelativeTime(true))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1614834367), got.Unix())
+}
@@ -1,7 +1,12 @@
 package reflecthelper
 
 import (
+	"math"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestParseTime(t *testing.T) {
@@ -59,3 +64,196 @@ func TestParseTime(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTimeLocation(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	assert.Nil(t, err)
+
+	layout := WithTimeLayouts("2006-01-02 15:04:05")
+	result, err := ParseTime("2021-01-03 15:04:05", layout, WithLocation(jakarta))
+	assert.Nil(t, err)
+	assert.Equal(t, jakarta, result.Location())
+	assert.Equal(t, 15, result.Hour())
+
+	result, err = ParseTime("2021-01-03 15:04:05", layout, WithLocation(jakarta), WithNormalizeTimeUTC(true))
+	assert.Nil(t, err)
+	assert.Equal(t, time.UTC, result.Location())
+	assert.Equal(t, 8, result.Hour())
+
+	result, err = ParseTime("2019-10-20T00:00:00+09:00", WithLocation(jakarta), WithNormalizeTimeUTC(true))
+	assert.Nil(t, err)
+	assert.True(t, time.Date(2019, 10, 19, 15, 0, 0, 0, time.UTC).Equal(result))
+	assert.Equal(t, time.UTC, result.Location())
+
+	result, err = ParseTime("2021-03-04 05:06", WithLocation(jakarta), WithTimeInference(true))
+	assert.Nil(t, err)
+	assert.True(t, time.Date(2021, 3, 4, 5, 6, 0, 0, jakarta).Equal(result))
+
+	result, err = ExtractTime(reflect.ValueOf(time.Date(2021, 3, 4, 5, 6, 0, 0, jakarta)), WithNormalizeTimeUTC(true))
+	assert.Nil(t, err)
+	assert.Equal(t, time.UTC, result.Location())
+}
+
+func TestAssignLocation(t *testing.T) {
+	var target struct {
+		Loc   *time.Location
+		Value time.Location
+	}
+	err := AssignReflect(reflect.ValueOf(&target.Loc), reflect.ValueOf("UTC"))
+	assert.Nil(t, err)
+	assert.True(t, target.Loc == time.UTC)
+
+	err = AssignReflect(reflect.ValueOf(&target.Loc), reflect.ValueOf("Asia/Jakarta"))
+	assert.Nil(t, err)
+	assert.Equal(t, "Asia/Jakarta", target.Loc.String())
+
+	err = AssignReflect(reflect.ValueOf(&target.Value), reflect.ValueOf("Local"))
+	assert.Equal(t, ErrAssignerCantSet, err)
+
+	err = AssignReflect(reflect.ValueOf(&target.Loc), reflect.ValueOf("Mars/Olympus"))
+	assert.NotNil(t, err)
+
+	// The shared location must not be overwritten.
+	target.Loc = time.UTC
+	err = AssignReflect(reflect.ValueOf(&target.Loc), reflect.ValueOf("Asia/Jakarta"))
+	assert.Nil(t, err)
+	assert.Equal(t, "UTC", time.UTC.String())
+	assert.Equal(t, "Asia/Jakarta", target.Loc.String())
+
+	err = AssignReflect(reflect.ValueOf(time.UTC), reflect.ValueOf("Asia/Tokyo"))
+	assert.Equal(t, ErrAssignerCantSet, err)
+	assert.Equal(t, "UTC", time.UTC.String())
+	assert.Equal(t, 0, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Hour())
+	_, offset := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Zone()
+	assert.Equal(t, 0, offset)
+
+	target.Loc = time.UTC
+	err = Merge(&target, map[string]interface{}{"Loc": "Asia/Tokyo"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Asia/Tokyo", target.Loc.String())
+	assert.Equal(t, "UTC", time.UTC.String())
+}
+
+func TestExtractTimeUnix(t *testing.T) {
+	want := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	tests := []struct {
+		name     string
+		input    interface{}
+		fnOpts   []FuncOption
+		wantTime time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "seconds",
+			input:    want.Unix(),
+			wantTime: want,
+		},
+		{
+			name:     "auto milliseconds",
+			input:    want.Unix() * 1000,
+			wantTime: want,
+		},
+		{
+			name:     "auto microseconds",
+			input:    uint64(want.Unix() * 1000000),
+			wantTime: want,
+		},
+		{
+			name:     "auto nanoseconds",
+			input:    want.UnixNano(),
+			wantTime: want,
+		},
+		{
+			name:     "explicit milliseconds",
+			input:    int32(1500),
+			fnOpts:   []FuncOption{WithUnixUnit(UnixMilli)},
+			wantTime: time.Unix(1, 500000000).UTC(),
+		},
+		{
+			name:     "fractional float seconds",
+			input:    float64(want.Unix()) + 0.123,
+			wantTime: want.Add(123 * time.Millisecond),
+		},
+		{
+			name:     "negative seconds",
+			input:    -1.5,
+			wantTime: time.Unix(-2, 500000000).UTC(),
+		},
+		{
+			name:     "numeric string",
+			input:    "1614834367.25",
+			wantTime: want.Add(250 * time.Millisecond),
+		},
+		{
+			name:     "numeric string in location",
+			input:    "1614834367",
+			fnOpts:   []FuncOption{WithLocation(time.FixedZone("WIB", 7*3600))},
+			wantTime: want,
+		},
+		{
+			name:    "not finite float",
+			input:   math.Inf(1),
+			wantErr: true,
+		},
+		{
+			name:    "overflowed seconds",
+			input:   "1e30",
+			fnOpts:  []FuncOption{WithUnixUnit(UnixSecond)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractTime(reflect.ValueOf(tt.input), tt.fnOpts...)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.True(t, tt.wantTime.Equal(got), "got %s want %s", got, tt.wantTime)
+		})
+	}
+
+	got, err := ExtractTime(reflect.ValueOf(want.Unix()), WithLocation(time.FixedZone("WIB", 7*3600)))
+	assert.Nil(t, err)
+	assert.Equal(t, "WIB", got.Location().String())
+}
+
+func TestAssignTimeToUnix(t *testing.T) {
+	input := time.Date(2021, 3, 4, 5, 6, 7, 8000000, time.UTC)
+	var target int64
+	err := AssignReflect(reflect.ValueOf(&target), reflect.ValueOf(input))
+	assert.NotNil(t, err)
+
+	err = AssignReflect(reflect.ValueOf(&target), reflect.ValueOf(input), WithTimeToUnix(true))
+	assert.Nil(t, err)
+	assert.Equal(t, input.Unix(), target)
+
+	err = AssignReflect(reflect.ValueOf(&target), reflect.ValueOf(&input), WithTimeToUnix(true), WithUnixUnit(UnixMilli))
+	assert.Nil(t, err)
+	assert.Equal(t, input.Unix()*1000+8, target)
+
+	var back time.Time
+	err = AssignReflect(reflect.ValueOf(&back), reflect.ValueOf(target))
+	assert.Nil(t, err)
+	assert.True(t, input.Equal(back))
+
+	before := time.Date(1969, 12, 31, 23, 59, 59, 500000000, time.UTC)
+	err = AssignReflect(reflect.ValueOf(&target), reflect.ValueOf(before), WithTimeToUnix(true), WithUnixUnit(UnixMilli))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-500), target)
+
+	far := time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)
+	err = AssignReflect(reflect.ValueOf(&target), reflect.ValueOf(far), WithTimeToUnix(true), WithUnixUnit(UnixMicro))
+	assert.Nil(t, err)
+	assert.Equal(t, far.Unix()*1000000, target)
+
+	target = 0
+	err = AssignReflect(reflect.ValueOf(&target), reflect.ValueOf(far), WithTimeToUnix(true), WithUnixUnit(UnixNano))
+	var overflowErr *OverflowError
+	assert.ErrorAs(t, err, &overflowErr)
+	assert.Equal(t, int64(0), target)
+
+	err = AssignReflect(reflect.ValueOf(&target), reflect.ValueOf(time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC)), WithTimeToUnix(true), WithUnixUnit(UnixNano))
+	assert.ErrorAs(t, err, &overflowErr)
+}
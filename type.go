@@ -5,6 +5,8 @@ import (
 	"net/url"
 	"reflect"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // List of reflect.Type used in this package
@@ -17,8 +19,16 @@ var (
 	TypeDuration    = reflect.TypeOf(time.Duration(0))
 	TypeURLPtr      = reflect.TypeOf(new(url.URL))
 	TypeURL         = reflect.TypeOf(url.URL{})
+	TypeLocationPtr = reflect.TypeOf(new(time.Location))
+	TypeLocation    = reflect.TypeOf(time.Location{})
 	TypeIPPtr       = reflect.TypeOf(new(net.IP))
 	TypeIP          = reflect.TypeOf(net.IP{})
+	TypeBool        = reflect.TypeOf(false)
+	TypeInt64       = reflect.TypeOf(int64(0))
+	TypeUint64      = reflect.TypeOf(uint64(0))
+	TypeFloat64     = reflect.TypeOf(float64(0))
+	TypeComplex128  = reflect.TypeOf(complex128(0))
+	TypeDecimal     = reflect.TypeOf(decimal.Decimal{})
 )
 
 // IsTypeValueElemable checks if the type of the reflect.Value can call Elem.
@@ -157,3 +167,9 @@ func IsTypeValueIP(val reflect.Value) bool {
 	typeVal := GetType(val)
 	return typeVal == TypeIP || typeVal == TypeIPPtr
 }
+
+// IsTypeValueLocation checks whether the type of val reflect.Value is time.Location or *time.Location.
+func IsTypeValueLocation(val reflect.Value) bool {
+	typeVal := GetType(val)
+	return typeVal == TypeLocation || typeVal == TypeLocationPtr
+}
@@ -0,0 +1,423 @@
+package reflecthelper
+
+import (
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// TagValidate is the struct tag key read by Validate.
+const TagValidate = "validate"
+
+// FuncValidationRule is a validation rule checking the val using the param of the rule, e.g. 18 in min=18.
+// The param is empty if the rule doesn't have any param.
+type FuncValidationRule func(val reflect.Value, param string) (err error)
+
+type funcBuiltinRule func(val reflect.Value, param string, opt *Option) (err error)
+
+var (
+	validationRules   = make(map[string]FuncValidationRule)
+	validationRulesMu sync.RWMutex
+	regexCache        sync.Map
+	builtinRules      map[string]funcBuiltinRule
+)
+
+func init() {
+	builtinRules = map[string]funcBuiltinRule{
+		"required": validateRequired,
+		"min":      getCompareRule("min", true),
+		"max":      getCompareRule("max", true),
+		"len":      getCompareRule("len", true),
+		"gt":       getCompareRule("gt", true),
+		"gte":      getCompareRule("gte", true),
+		"lt":       getCompareRule("lt", true),
+		"lte":      getCompareRule("lte", true),
+		"eq":       getCompareRule("eq", false),
+		"ne":       getCompareRule("ne", false),
+		"oneof":    validateOneOf,
+		"regex":    validateRegex,
+		"email":    validateEmail,
+		"url":      validateURL,
+		"ip":       validateIP,
+	}
+}
+
+// RegisterValidationRule registers the custom validation rule used by Validate.
+// The custom rule overrides the built-in rule having the same name.
+// The registered rule is not called for nil values.
+func RegisterValidationRule(name string, fn FuncValidationRule) {
+	validationRulesMu.Lock()
+	defer validationRulesMu.Unlock()
+
+	if fn == nil {
+		delete(validationRules, name)
+		return
+	}
+	validationRules[name] = fn
+}
+
+func getValidationRule(name string) (res funcBuiltinRule, ok bool) {
+	validationRulesMu.RLock()
+	custom, ok := validationRules[name]
+	validationRulesMu.RUnlock()
+	if ok {
+		res = func(val reflect.Value, param string, opt *Option) error {
+			return custom(val, param)
+		}
+		return
+	}
+
+	res, ok = builtinRules[name]
+	return
+}
+
+// ValidationError is the error of a single validation rule along with the path of the validated value.
+type ValidationError struct {
+	Path  Path
+	Rule  string
+	Param string
+	Err   error
+}
+
+// Error returns the error message of the validation error.
+func (e *ValidationError) Error() string {
+	rule := e.Rule
+	if e.Param != "" {
+		rule += "=" + e.Param
+	}
+	if len(e.Path) == 0 {
+		return "rule " + rule + ": " + e.Err.Error()
+	}
+	return e.Path.String() + ": rule " + rule + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error returned from the validation rule.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+type validationRule struct {
+	name  string
+	param string
+}
+
+// parseValidationTag parses the tag into the list of rules, the commas inside the param can be escaped using backslash.
+func parseValidationTag(tag string) (rules []validationRule, omitEmpty bool) {
+	var builder strings.Builder
+	appendRule := func() {
+		text := strings.TrimSpace(builder.String())
+		builder.Reset()
+		if text == "" {
+			return
+		}
+		if text == "omitempty" {
+			omitEmpty = true
+			return
+		}
+
+		rule := validationRule{name: text}
+		if index := strings.IndexByte(text, '='); index >= 0 {
+			rule.name, rule.param = text[:index], text[index+1:]
+		}
+		rules = append(rules, rule)
+	}
+	for index := 0; index < len(tag); index++ {
+		switch {
+		case tag[index] == '\\' && index+1 < len(tag) && tag[index+1] == ',':
+			index++
+			builder.WriteByte(',')
+		case tag[index] == ',':
+			appendRule()
+		default:
+			builder.WriteByte(tag[index])
+		}
+	}
+	appendRule()
+	return
+}
+
+type validator struct {
+	opt       *Option
+	errs      MultiError
+	ancestors map[valueVisit]struct{}
+}
+
+func newValidator(opt *Option) *validator {
+	return &validator{
+		opt:       opt,
+		ancestors: make(map[valueVisit]struct{}),
+	}
+}
+
+func (v *validator) addError(path Path, rule validationRule, err error) {
+	v.errs = append(v.errs, &ValidationError{
+		Path:  path,
+		Rule:  rule.name,
+		Param: rule.param,
+		Err:   err,
+	})
+}
+
+func (v *validator) validate(val reflect.Value, path Path) {
+	visit, tracked := getValueVisit(val)
+	if tracked {
+		if _, ok := v.ancestors[visit]; ok {
+			return
+		}
+		v.ancestors[visit] = struct{}{}
+		defer delete(v.ancestors, visit)
+	}
+
+	val = GetChildElem(val)
+	switch GetKind(val) {
+	case reflect.Struct:
+		v.validateStruct(val, path)
+	case reflect.Array, reflect.Slice:
+		for index := 0; index < val.Len(); index++ {
+			v.validate(val.Index(index), path.append(PathSegment{Kind: PathIndex, Index: index}))
+		}
+	case reflect.Map:
+		for _, entry := range getMapEntries(val, v.opt) {
+			v.validate(entry.value, path.append(PathSegment{Kind: PathMapKey, Key: entry.key}))
+		}
+	}
+}
+
+func (v *validator) validateStruct(val reflect.Value, path Path) {
+	if IsTypeValueTime(val) {
+		return
+	}
+
+	typ := val.Type()
+	for index := 0; index < val.NumField(); index++ {
+		field := typ.Field(index)
+		tag := field.Tag.Get(TagValidate)
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+
+		fieldPath := path.append(PathSegment{Kind: PathField, Name: field.Name})
+		v.validateField(val.Field(index), tag, fieldPath)
+		v.validate(val.Field(index), fieldPath)
+	}
+}
+
+func (v *validator) validateField(val reflect.Value, tag string, path Path) {
+	rules, omitEmpty := parseValidationTag(tag)
+	if omitEmpty && IsValueZero(val) {
+		return
+	}
+
+	isNil := isCompareNil(GetChildElem(val))
+	for _, rule := range rules {
+		fn, ok := getValidationRule(rule.name)
+		if !ok {
+			v.addError(path, rule, getErrUnknownRule(rule.name))
+			continue
+		}
+		if isNil && rule.name != "required" {
+			continue
+		}
+
+		if err := fn(val, rule.param, v.opt); err != nil {
+			v.addError(path, rule, err)
+		}
+	}
+}
+
+func validateRequired(val reflect.Value, param string, opt *Option) (err error) {
+	if IsValueZero(val) {
+		err = ErrRequired
+	}
+	return
+}
+
+func getCompareRule(name string, isSize bool) funcBuiltinRule {
+	return func(val reflect.Value, param string, opt *Option) (err error) {
+		cmp, isLength, err := compareRuleParam(GetChildElem(val), param, isSize, opt)
+		if err != nil {
+			return
+		}
+
+		var ok bool
+		switch name {
+		case "min", "gte":
+			ok = cmp >= 0
+		case "max", "lte":
+			ok = cmp <= 0
+		case "gt":
+			ok = cmp > 0
+		case "lt":
+			ok = cmp < 0
+		case "len", "eq":
+			ok = cmp == 0
+		case "ne":
+			ok = cmp != 0
+		}
+		if !ok {
+			err = getErrRuleCompare(name, param, isLength)
+		}
+		return
+	}
+}
+
+// compareRuleParam compares the val with the param of the rule.
+// The length of the val is compared instead if the val is a string and isSize is true,
+// or if the val is an array, chan, map, or slice.
+func compareRuleParam(val reflect.Value, param string, isSize bool, opt *Option) (res int, isLength bool, err error) {
+	paramVal := reflect.ValueOf(param)
+	switch kind := GetKind(val); {
+	case IsTypeValueTime(val):
+		var left, right time.Time
+		left, err = extractTime(val, opt.resetCheck())
+		if err != nil {
+			return
+		}
+		right, err = extractTime(paramVal, opt.resetCheck())
+		if err != nil {
+			return
+		}
+		res = compareOrdered(left.Before(right), left.After(right))
+	case IsTypeValueDuration(val):
+		var right time.Duration
+		right, err = extractDuration(paramVal, opt.resetCheck())
+		if err != nil {
+			return
+		}
+		left := time.Duration(val.Int())
+		res = compareOrdered(left < right, left > right)
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		var right int64
+		right, err = extractInt(paramVal, opt.resetCheck())
+		if err != nil {
+			return
+		}
+		res = compareOrdered(val.Int() < right, val.Int() > right)
+	case kind >= reflect.Uint && kind <= reflect.Uintptr:
+		var right uint64
+		right, err = extractUint(paramVal, opt.resetCheck())
+		if err != nil {
+			return
+		}
+		res = compareOrdered(val.Uint() < right, val.Uint() > right)
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		var right float64
+		right, err = extractFloat(paramVal, opt.resetCheck())
+		if err != nil {
+			return
+		}
+		res = compareOrdered(val.Float() < right, val.Float() > right)
+	case kind == reflect.Bool:
+		var right bool
+		right, err = extractBool(paramVal, opt.resetCheck())
+		if err != nil {
+			return
+		}
+		res = compareOrdered(!val.Bool() && right, val.Bool() && !right)
+	case kind == reflect.String && !isSize:
+		res = strings.Compare(val.String(), param)
+	case kind == reflect.String, kind == reflect.Array, kind == reflect.Chan, kind == reflect.Map, kind == reflect.Slice:
+		var right int64
+		right, err = extractInt(paramVal, opt.resetCheck())
+		if err != nil {
+			return
+		}
+		left := int64(val.Len())
+		if kind == reflect.String {
+			left = int64(utf8.RuneCountInString(val.String()))
+		}
+		isLength = true
+		res = compareOrdered(left < right, left > right)
+	default:
+		err = getErrUnimplementedExtract(val)
+	}
+	return
+}
+
+func compareOrdered(isLess bool, isGreater bool) int {
+	switch {
+	case isLess:
+		return -1
+	case isGreater:
+		return 1
+	}
+	return 0
+}
+
+func validateOneOf(val reflect.Value, param string, opt *Option) (err error) {
+	str, err := extractString(val, opt.resetCheck())
+	if err != nil {
+		return
+	}
+
+	for _, option := range strings.Fields(param) {
+		if str == option {
+			return
+		}
+	}
+	err = getErrRuleOneOf(param)
+	return
+}
+
+func getCachedRegex(pattern string) (res *regexp.Regexp, err error) {
+	if cached, ok := regexCache.Load(pattern); ok {
+		res = cached.(*regexp.Regexp)
+		return
+	}
+
+	res, err = regexp.Compile(pattern)
+	if err != nil {
+		return
+	}
+	regexCache.Store(pattern, res)
+	return
+}
+
+func validateRegex(val reflect.Value, param string, opt *Option) (err error) {
+	regex, err := getCachedRegex(param)
+	if err != nil {
+		return
+	}
+	str, err := extractString(val, opt.resetCheck())
+	if err != nil {
+		return
+	}
+
+	if !regex.MatchString(str) {
+		err = getErrRuleRegex(param)
+	}
+	return
+}
+
+func validateEmail(val reflect.Value, param string, opt *Option) (err error) {
+	str, err := extractString(val, opt.resetCheck())
+	if err != nil {
+		return
+	}
+
+	address, parseErr := mail.ParseAddress(str)
+	if parseErr != nil || address.Address != str {
+		err = getErrRuleFormat("email")
+	}
+	return
+}
+
+func validateURL(val reflect.Value, param string, opt *Option) (err error) {
+	res, extractErr := extractURL(val, opt.resetCheck())
+	if extractErr != nil || res == nil || res.Scheme == "" || res.Host == "" {
+		err = getErrRuleFormat("url")
+	}
+	return
+}
+
+func validateIP(val reflect.Value, param string, opt *Option) (err error) {
+	res, extractErr := extractIP(val, opt.resetCheck())
+	if extractErr != nil || res == nil {
+		err = getErrRuleFormat("ip")
+	}
+	return
+}
@@ -0,0 +1,32 @@
+package reflecthelper
+
+// Validate validates the input recursively using the rules inside the validate struct tag,
+// e.g. `validate:"required,min=1,max=100,oneof=a b,regex=^x,email,url"`.
+// The input can be an interface{} or a reflect.Value.
+// Validate recurses into the nested structs, arrays, slices, and map values.
+// Unexported fields and fields tagged with `validate:"-"` are skipped.
+//
+// The built-in rules are required, omitempty, min, max, len, gt, gte, lt, lte, eq, ne, oneof, regex, email, url, and ip.
+// The min, max, len, gt, gte, lt, and lte rules compare the length of strings, arrays, chans, maps, and slices,
+// and compare the value of numbers, time.Duration, and time.Time.
+// The commas inside the param of a rule can be escaped using backslash, e.g. `validate:"regex=^a{1\\,3}$"`.
+// Custom rules can be registered using RegisterValidationRule.
+// The rules other than required are skipped for nil values.
+//
+// Validate returns MultiError containing all *ValidationError if any of the rules fails.
+func Validate(input interface{}, fnOpts ...FuncOption) (err error) {
+	opt := NewOption().Assign(fnOpts...)
+	defer recoverFnOpt(&err, opt)
+
+	val := getValFromInterface(input)
+	if !val.IsValid() {
+		return
+	}
+
+	v := newValidator(opt)
+	v.validate(val, make(Path, 0))
+	if len(v.errs) > 0 {
+		err = v.errs
+	}
+	return
+}
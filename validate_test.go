@@ -0,0 +1,197 @@
+package reflecthelper
+
+import (
+	"errors"
+	"math"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type validateAddress struct {
+	City    string `validate:"required"`
+	Country string `validate:"oneof=ID MY SG"`
+}
+
+type validateUser struct {
+	Name      string                     `validate:"required,min=2,max=10"`
+	Age       int                        `validate:"gte=18,lt=150"`
+	Score     float64                    `validate:"max=10.5"`
+	Code      string                     `validate:"omitempty,regex=^[A-Z]{2\\,3}$"`
+	Email     string                     `validate:"omitempty,email"`
+	Website   string                     `validate:"omitempty,url"`
+	IP        string                     `validate:"omitempty,ip"`
+	Tags      []string                   `validate:"min=1"`
+	Timeout   time.Duration              `validate:"omitempty,min=1s,max=1m"`
+	Birth     time.Time                  `validate:"omitempty,lt=2020-01-01T00:00:00Z"`
+	Address   *validateAddress           `validate:"required"`
+	Others    []validateAddress          `validate:"max=2"`
+	Branches  map[string]validateAddress `validate:"-"`
+	Offices   map[string]*validateAddress
+	Nickname  *string `validate:"min=3"`
+	Ignored   string  `validate:"-"`
+	unexposed string  `validate:"required"`
+}
+
+func getValidUser() validateUser {
+	return validateUser{
+		Name:    "budi",
+		Age:     20,
+		Score:   10.5,
+		Code:    "ABC",
+		Email:   "budi@example.com",
+		Website: "https://example.com/path",
+		IP:      "10.0.0.1",
+		Tags:    []string{"admin"},
+		Timeout: 30 * time.Second,
+		Birth:   time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		Address: &validateAddress{City: "Jakarta", Country: "ID"},
+	}
+}
+
+func getValidationPaths(err error) (res []string) {
+	var multiErr MultiError
+	if !errors.As(err, &multiErr) {
+		return
+	}
+	for _, elemErr := range multiErr {
+		var validationErr *ValidationError
+		if errors.As(elemErr, &validationErr) {
+			res = append(res, validationErr.Path.String()+" "+validationErr.Rule)
+		}
+	}
+	return
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		user := getValidUser()
+		assert.Nil(t, Validate(user))
+		assert.Nil(t, Validate(&user))
+		assert.Nil(t, Validate(reflect.ValueOf(user)))
+		assert.Nil(t, Validate(nil))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		nickname := "ab"
+		user := getValidUser()
+		user.Name = "b"
+		user.Age = 17
+		user.Score = 11
+		user.Code = "abc"
+		user.Email = "Budi <budi@example.com>"
+		user.Website = "example.com"
+		user.IP = "10.0.0"
+		user.Tags = nil
+		user.Timeout = time.Hour
+		user.Birth = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		user.Others = []validateAddress{{Country: "ID"}, {City: "KL", Country: "MY"}, {City: "SG", Country: "SG"}}
+		user.Branches = map[string]validateAddress{"x": {}}
+		user.Offices = map[string]*validateAddress{"b": {City: "x", Country: "US"}, "a": nil}
+		user.Nickname = &nickname
+
+		err := Validate(user, WithSortedMapKeys(true))
+		assert.NotNil(t, err)
+		assert.Equal(t, []string{
+			"Name min",
+			"Age gte",
+			"Score max",
+			"Code regex",
+			"Email email",
+			"Website url",
+			"IP ip",
+			"Tags min",
+			"Timeout max",
+			"Birth lt",
+			"Others max",
+			"Others[0].City required",
+			`Offices["b"].Country oneof`,
+			"Nickname min",
+		}, getValidationPaths(err))
+		assert.True(t, errors.Is(err, ErrRequired))
+		assert.Contains(t, err.Error(), "Name: rule min=2: the length must be greater than or equal to 2")
+		assert.Contains(t, err.Error(), "Age: rule gte=18: the value must be greater than or equal to 18")
+	})
+
+	t.Run("required nil pointer", func(t *testing.T) {
+		user := getValidUser()
+		user.Address = nil
+		assert.Equal(t, []string{"Address required"}, getValidationPaths(Validate(user)))
+	})
+
+	t.Run("eq and ne", func(t *testing.T) {
+		type input struct {
+			Status string `validate:"eq=active"`
+			Count  uint   `validate:"ne=0"`
+			Flag   bool   `validate:"eq=true"`
+		}
+		assert.Nil(t, Validate(input{Status: "active", Count: 1, Flag: true}))
+		assert.Equal(t, []string{"Status eq", "Count ne", "Flag eq"}, getValidationPaths(Validate(input{Status: "inactive"})))
+	})
+
+	t.Run("unknown rule and invalid param", func(t *testing.T) {
+		type input struct {
+			Name string `validate:"unknown"`
+			Age  int    `validate:"min=abc"`
+			Code string `validate:"regex=("`
+		}
+		assert.Equal(t, []string{"Name unknown", "Age min", "Code regex"}, getValidationPaths(Validate(input{Code: "x"})))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		type node struct {
+			Name string `validate:"required"`
+			Next *node
+		}
+		first := &node{Name: "first"}
+		first.Next = &node{Next: first}
+		assert.Equal(t, []string{"Next.Name required"}, getValidationPaths(Validate(first)))
+	})
+
+	t.Run("non struct root", func(t *testing.T) {
+		list := []validateAddress{{City: "x", Country: "ID"}, {Country: "ID"}}
+		assert.Equal(t, []string{"[1].City required"}, getValidationPaths(Validate(list)))
+	})
+}
+
+func TestRegisterValidationRule(t *testing.T) {
+	type input struct {
+		Name string `validate:"upper,required"`
+		IP   net.IP `validate:"loopback"`
+	}
+	RegisterValidationRule("upper", func(val reflect.Value, param string) (err error) {
+		if strings.ToUpper(val.String()) != val.String() {
+			err = ErrTesting
+		}
+		return
+	})
+	RegisterValidationRule("loopback", func(val reflect.Value, param string) (err error) {
+		if !GetIP(val).IsLoopback() {
+			err = ErrTesting
+		}
+		return
+	})
+	defer RegisterValidationRule("upper", nil)
+	defer RegisterValidationRule("loopback", nil)
+
+	assert.Nil(t, Validate(input{Name: "ABC", IP: net.ParseIP("127.0.0.1")}))
+	err := Validate(input{Name: "abc", IP: net.ParseIP("8.8.8.8")})
+	assert.Equal(t, []string{"Name upper", "IP loopback"}, getValidationPaths(err))
+	assert.True(t, errors.Is(err, ErrTesting))
+}
+
+func TestValidateMapNaNKey(t *testing.T) {
+	type item struct {
+		Name string `validate:"required"`
+	}
+	input := map[float64]item{math.NaN(): {}, 1: {Name: "a"}}
+	err := Validate(input)
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "required", validationErr.Rule)
+	assert.True(t, math.IsNaN(validationErr.Path[0].Key.Float()))
+}
@@ -1,7 +1,9 @@
 package reflecthelper
 
 import (
+	"context"
 	"reflect"
+	"time"
 
 	"github.com/fairyhunter13/task/v2"
 )
@@ -27,12 +29,14 @@ type Value struct {
 	kind reflect.Kind
 	err  error
 	opt  *Option
+	errs *errorCollector
 }
 
 func (s *Value) init() *Value {
 	if s.opt == nil {
 		s.opt = NewDefaultOption()
 	}
+	s.errs = new(errorCollector)
 	return s
 }
 
@@ -53,16 +57,84 @@ func (s *Value) isChan() bool {
 	return IsKindChan(s.kind) && s.CanSet()
 }
 
-func (s *Value) iterateEachStruct(fns []IterStructFn, index int) (err error) {
+func (s *Value) newWorkerLimiter() (sem chan struct{}) {
+	if s.opt.ConcurrentMode && s.opt.MaxWorkers > 0 {
+		sem = make(chan struct{}, s.opt.MaxWorkers)
+	}
+	return
+}
+
+func (s *Value) runIteration(ctx context.Context, tm *task.ErrorManager, sem chan struct{}, fn func() error) (err error) {
+	err = ctx.Err()
+	if err != nil {
+		return
+	}
+	if !s.opt.ConcurrentMode {
+		err = fn()
+		return
+	}
+
+	if sem != nil {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		}
+	}
+	// The panic inside the goroutine must be recovered there, otherwise it crashes the process.
+	tm.Run(func() (err error) {
+		if sem != nil {
+			defer func() { <-sem }()
+		}
+		err = ctx.Err()
+		if err != nil {
+			return
+		}
+		err = SafeCall(fn)
+		return
+	})
+	return
+}
+
+// waitIteration waits for the running iteration and combines the err stopping the iteration, e.g. ctx.Err(),
+// with the errors returned or collected from the elements, so that the element errors are not lost on cancellation.
+func (s *Value) waitIteration(tm *task.ErrorManager, err error) error {
+	var errs MultiError
+	for _, each := range []error{tm.Error(), s.errs.error(), err} {
+		errs = appendUniqueError(errs, each)
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return errs
+}
+
+func (s *Value) handleIterError(err error, order int, elemErr ElemError) error {
+	if err == nil || s.opt.IgnoreError {
+		return nil
+	}
+	if s.opt.CollectErrors {
+		elemErr.Err = err
+		s.errs.add(order, &elemErr)
+		return nil
+	}
+	return err
+}
+
+func (s *Value) iterateEachStruct(fns []IterStructFn, structVal reflect.Value, index int) (err error) {
 	for _, fn := range fns {
 		if fn == nil {
 			continue
 		}
-		err = fn(s.Value, s.Field(index))
-		if s.opt.IgnoreError {
-			err = nil
-			continue
-		}
+		err = s.handleIterError(fn(structVal, exposeValueOpt(structVal.Field(index), s.opt)), index, ElemError{
+			Kind:  reflect.Struct,
+			Field: structVal.Type().Field(index).Name,
+			Index: index,
+		})
 		if err != nil {
 			return
 		}
@@ -70,24 +142,22 @@ func (s *Value) iterateEachStruct(fns []IterStructFn, index int) (err error) {
 	return
 }
 
-func (s *Value) iterateStruct(fns []IterStructFn) (err error) {
-	numField := s.NumField()
+func (s *Value) iterateStruct(ctx context.Context, fns []IterStructFn) (err error) {
+	// The addressable copy is kept local so that the s.Value still refers to the original struct.
+	structVal := getAddressableStruct(s.Value, s.opt)
+	numField := structVal.NumField()
 	tm := task.NewErrorManager(task.WithBufferSize(numField))
+	sem := s.newWorkerLimiter()
 	for index := 0; index < numField; index++ {
 		index := index
-		if s.opt.ConcurrentMode {
-			tm.Run(func() (err error) {
-				err = s.iterateEachStruct(fns, index)
-				return
-			})
-			continue
-		}
-		err = s.iterateEachStruct(fns, index)
+		err = s.runIteration(ctx, tm, sem, func() error {
+			return s.iterateEachStruct(fns, structVal, index)
+		})
 		if err != nil {
-			return
+			break
 		}
 	}
-	err = tm.Error()
+	err = s.waitIteration(tm, err)
 	return
 }
 
@@ -96,11 +166,10 @@ func (s *Value) iterateEachArraySlice(fns []IterArraySliceFn, index int) (err er
 		if fn == nil {
 			continue
 		}
-		err = fn(s.Value, index, s.Index(index))
-		if s.opt.IgnoreError {
-			err = nil
-			continue
-		}
+		err = s.handleIterError(fn(s.Value, index, s.Index(index)), index, ElemError{
+			Kind:  s.kind,
+			Index: index,
+		})
 		if err != nil {
 			return
 		}
@@ -108,37 +177,32 @@ func (s *Value) iterateEachArraySlice(fns []IterArraySliceFn, index int) (err er
 	return
 }
 
-func (s *Value) iterateArraySlice(fns []IterArraySliceFn) (err error) {
+func (s *Value) iterateArraySlice(ctx context.Context, fns []IterArraySliceFn) (err error) {
 	lenList := s.Len()
 	tm := task.NewErrorManager(task.WithBufferSize(lenList))
+	sem := s.newWorkerLimiter()
 	for index := 0; index < lenList; index++ {
 		index := index
-		if s.opt.ConcurrentMode {
-			tm.Run(func() (err error) {
-				err = s.iterateEachArraySlice(fns, index)
-				return
-			})
-			continue
-		}
-		err = s.iterateEachArraySlice(fns, index)
+		err = s.runIteration(ctx, tm, sem, func() error {
+			return s.iterateEachArraySlice(fns, index)
+		})
 		if err != nil {
-			return
+			break
 		}
 	}
-	err = tm.Error()
+	err = s.waitIteration(tm, err)
 	return
 }
 
-func (s *Value) iterateEachMap(fns []IterMapFn, key reflect.Value, val reflect.Value) (err error) {
+func (s *Value) iterateEachMap(fns []IterMapFn, order int, key reflect.Value, val reflect.Value) (err error) {
 	for _, fn := range fns {
 		if fn == nil {
 			continue
 		}
-		err = fn(s.Value, key, val)
-		if s.opt.IgnoreError {
-			err = nil
-			continue
-		}
+		err = s.handleIterError(fn(s.Value, key, val), order, ElemError{
+			Kind: reflect.Map,
+			Key:  key,
+		})
 		if err != nil {
 			return
 		}
@@ -146,38 +210,43 @@ func (s *Value) iterateEachMap(fns []IterMapFn, key reflect.Value, val reflect.V
 	return
 }
 
-func (s *Value) iterateMap(fns []IterMapFn) (err error) {
+func (s *Value) iterateMap(ctx context.Context, fns []IterMapFn) (err error) {
 	tm := task.NewErrorManager(task.WithBufferSize(s.Len()))
-	iter := s.MapRange()
-	for iter.Next() {
-		key := iter.Key()
-		val := iter.Value()
-		if s.opt.ConcurrentMode {
-			tm.Run(func() (err error) {
-				err = s.iterateEachMap(fns, key, val)
-				return
-			})
-			continue
+	sem := s.newWorkerLimiter()
+	iterate := func(order int, key reflect.Value, val reflect.Value) error {
+		return s.runIteration(ctx, tm, sem, func() error {
+			return s.iterateEachMap(fns, order, key, val)
+		})
+	}
+	if s.opt.SortMapKeys {
+		for order, entry := range getMapEntries(s.Value, s.opt) {
+			err = iterate(order, entry.key, entry.value)
+			if err != nil {
+				break
+			}
 		}
-		err = s.iterateEachMap(fns, key, val)
-		if err != nil {
-			return
+	} else {
+		iter := s.MapRange()
+		for order := 0; iter.Next(); order++ {
+			err = iterate(order, iter.Key(), iter.Value())
+			if err != nil {
+				break
+			}
 		}
 	}
-	err = tm.Error()
+	err = s.waitIteration(tm, err)
 	return
 }
 
-func (s *Value) iterateEachChan(fns []IterChanFn, recv reflect.Value) (err error) {
+func (s *Value) iterateEachChan(fns []IterChanFn, order int, recv reflect.Value) (err error) {
 	for _, fn := range fns {
 		if fn == nil {
 			continue
 		}
-		err = fn(s.Value, recv)
-		if s.opt.IgnoreError {
-			err = nil
-			continue
-		}
+		err = s.handleIterError(fn(s.Value, recv), order, ElemError{
+			Kind:  reflect.Chan,
+			Index: order,
+		})
 		if err != nil {
 			return
 		}
@@ -185,38 +254,62 @@ func (s *Value) iterateEachChan(fns []IterChanFn, recv reflect.Value) (err error
 	return
 }
 
-func (s *Value) iterateChan(fns []IterChanFn) (err error) {
+func (s *Value) recvChan(ctx context.Context) (recv reflect.Value, ok bool, err error) {
+	if !s.opt.BlockChannelIteration {
+		recv, ok = s.TryRecv()
+		return
+	}
+
+	cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: s.Value}}
+	doneIndex, timeoutIndex := -1, -1
+	if done := ctx.Done(); done != nil {
+		doneIndex = len(cases)
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)})
+	}
+	if s.opt.ChannelTimeout > 0 {
+		timer := time.NewTimer(s.opt.ChannelTimeout)
+		defer timer.Stop()
+		timeoutIndex = len(cases)
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)})
+	}
+
+	chosen, recv, ok := reflect.Select(cases)
+	switch chosen {
+	case doneIndex:
+		recv, ok, err = reflect.Value{}, false, ctx.Err()
+	case timeoutIndex:
+		recv, ok, err = reflect.Value{}, false, ErrChannelTimeout
+	}
+	return
+}
+
+func (s *Value) iterateChan(ctx context.Context, fns []IterChanFn) (err error) {
 	tm := task.NewErrorManager(task.WithBufferSize(s.Len()))
-	for {
+	sem := s.newWorkerLimiter()
+	for order := 0; ; order++ {
 		var (
 			recv reflect.Value
 			ok   bool
 		)
-		if s.opt.BlockChannelIteration {
-			recv, ok = s.Recv()
-		} else {
-			recv, ok = s.TryRecv()
-		}
-		if !ok {
+		recv, ok, err = s.recvChan(ctx)
+		if err != nil || !ok {
 			break
 		}
-		if s.opt.ConcurrentMode {
-			tm.Run(func() (err error) {
-				err = s.iterateEachChan(fns, recv)
-				return
-			})
-			continue
-		}
-		err = s.iterateEachChan(fns, recv)
+		order := order
+		err = s.runIteration(ctx, tm, sem, func() error {
+			return s.iterateEachChan(fns, order, recv)
+		})
 		if err != nil {
-			return
+			break
 		}
 	}
-	err = tm.Error()
+	err = s.waitIteration(tm, err)
 	return
 }
 
 // Error returns the error contained within the Value.
+// If the CollectErrors option is true, the error is a MultiError containing all *ElemError.
+// If the iteration is stopped by the ctx, the ctx error is combined with the element errors into a MultiError.
 func (s *Value) Error() error {
 	return s.err
 }
@@ -232,48 +325,76 @@ func (s *Value) Assign(fnOpts ...FuncOption) *Value {
 
 // IterateStruct iterates the struct field using the IterStructFn.
 func (s *Value) IterateStruct(fns ...IterStructFn) *Value {
+	return s.IterateStructContext(context.Background(), fns...)
+}
+
+// IterateStructContext is like IterateStruct but it stops the iteration when the ctx is done.
+func (s *Value) IterateStructContext(ctx context.Context, fns ...IterStructFn) *Value {
 	if !s.init().isStruct() {
 		return s
 	}
 
 	defer recoverFnOpt(&s.err, s.opt)
-	s.err = s.iterateStruct(fns)
+	s.err = s.iterateStruct(getContext(ctx), fns)
 	return s
 }
 
 // IterateArraySlice iterates the element of slice or array using the IterArraySliceFn.
 func (s *Value) IterateArraySlice(fns ...IterArraySliceFn) *Value {
+	return s.IterateArraySliceContext(context.Background(), fns...)
+}
+
+// IterateArraySliceContext is like IterateArraySlice but it stops the iteration when the ctx is done.
+func (s *Value) IterateArraySliceContext(ctx context.Context, fns ...IterArraySliceFn) *Value {
 	if !s.init().isArrayOrSlice() {
 		return s
 	}
 
 	defer recoverFnOpt(&s.err, s.opt)
-	s.err = s.iterateArraySlice(fns)
+	s.err = s.iterateArraySlice(getContext(ctx), fns)
 	return s
 }
 
 // IterateMap iterates the element of map using the IterMapFn.
 func (s *Value) IterateMap(fns ...IterMapFn) *Value {
+	return s.IterateMapContext(context.Background(), fns...)
+}
+
+// IterateMapContext is like IterateMap but it stops the iteration when the ctx is done.
+func (s *Value) IterateMapContext(ctx context.Context, fns ...IterMapFn) *Value {
 	if !s.init().isMap() {
 		return s
 	}
 
 	defer recoverFnOpt(&s.err, s.opt)
-	s.err = s.iterateMap(fns)
+	s.err = s.iterateMap(getContext(ctx), fns)
 	return s
 }
 
 // IterateChan iterates the received elements using IterChanFn.
 func (s *Value) IterateChan(fns ...IterChanFn) *Value {
+	return s.IterateChanContext(context.Background(), fns...)
+}
+
+// IterateChanContext is like IterateChan but it stops the iteration when the ctx is done.
+// The blocking receive is also interrupted when the ctx is done.
+func (s *Value) IterateChanContext(ctx context.Context, fns ...IterChanFn) *Value {
 	if !s.init().isChan() {
 		return s
 	}
 
 	defer recoverFnOpt(&s.err, s.opt)
-	s.err = s.iterateChan(fns)
+	s.err = s.iterateChan(getContext(ctx), fns)
 	return s
 }
 
+func getContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
+
 // Cast casts the val of reflect.Value to the Value of this package.
 func Cast(val reflect.Value, fnOpts ...FuncOption) (res Value) {
 	val = GetChildElem(val)
@@ -0,0 +1,236 @@
+package reflecthelper
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/fairyhunter13/task/v2"
+)
+
+// FieldInfo contains the metadata and the value of a struct field.
+// The Index of the embedded reflect.StructField is the full index sequence from the iterated struct,
+// so it can be used with reflect.Value.FieldByIndex.
+type FieldInfo struct {
+	reflect.StructField
+	// Value is the value of the field.
+	Value reflect.Value
+	// Depth is the embedding depth of the field, 0 for the direct field of the struct.
+	Depth int
+}
+
+// IsExported checks whether the field is exported.
+func (f FieldInfo) IsExported() bool {
+	return f.PkgPath == ""
+}
+
+// IterStructFieldFn is a function type to iterate each field of structInput with its FieldInfo and returning an error if needed.
+type IterStructFieldFn func(structInput reflect.Value, info FieldInfo) error
+
+type structField struct {
+	reflect.StructField
+	depth int
+}
+
+type structFieldsKey struct {
+	typ     reflect.Type
+	flatten bool
+}
+
+var structFieldsCache sync.Map
+
+type embeddedStruct struct {
+	typ   reflect.Type
+	index []int
+}
+
+func getStructFields(typ reflect.Type, flatten bool) (res []structField) {
+	key := structFieldsKey{typ, flatten}
+	if cached, ok := structFieldsCache.Load(key); ok {
+		res = cached.([]structField)
+		return
+	}
+
+	if flatten {
+		res = getFlattenStructFields(typ)
+	} else {
+		res = make([]structField, 0, typ.NumField())
+		for index := 0; index < typ.NumField(); index++ {
+			res = append(res, structField{StructField: typ.Field(index)})
+		}
+	}
+	structFieldsCache.Store(key, res)
+	return
+}
+
+// getFlattenStructFields lists the fields of typ with the embedded struct fields promoted using the Go promotion rules.
+// The shallowest field wins and the ambiguous fields at the same depth are dropped.
+func getFlattenStructFields(typ reflect.Type) (res []structField) {
+	var (
+		candidates []structField
+		current    = []embeddedStruct{{typ: typ}}
+		visited    = make(map[reflect.Type]struct{})
+	)
+	for depth := 0; len(current) > 0; depth++ {
+		var next []embeddedStruct
+		for _, embedded := range current {
+			// The type embedded at the shallower depth is skipped to stop the recursion through the pointer cycles,
+			// its fields are shadowed by the shallower ones anyway.
+			// The type embedded more than once at the same depth is not skipped, so its fields become ambiguous.
+			if _, ok := visited[embedded.typ]; ok {
+				continue
+			}
+
+			for index := 0; index < embedded.typ.NumField(); index++ {
+				field := embedded.typ.Field(index)
+				field.Index = append(append(make([]int, 0, len(embedded.index)+1), embedded.index...), index)
+
+				fieldType := GetTypeChildElemPtrType(field.Type)
+				if field.Anonymous && IsKindStruct(fieldType.Kind()) {
+					next = append(next, embeddedStruct{typ: fieldType, index: field.Index})
+					continue
+				}
+				candidates = append(candidates, structField{StructField: field, depth: depth})
+			}
+		}
+		for _, embedded := range current {
+			visited[embedded.typ] = struct{}{}
+		}
+		current = next
+	}
+
+	var (
+		shallowest = make(map[string]int)
+		counts     = make(map[string]int)
+	)
+	for _, candidate := range candidates {
+		depth, ok := shallowest[candidate.Name]
+		switch {
+		case !ok || candidate.depth < depth:
+			shallowest[candidate.Name] = candidate.depth
+			counts[candidate.Name] = 1
+		case candidate.depth == depth:
+			counts[candidate.Name]++
+		}
+	}
+	for _, candidate := range candidates {
+		if shallowest[candidate.Name] == candidate.depth && counts[candidate.Name] == 1 {
+			res = append(res, candidate)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return isIndexLess(res[i].Index, res[j].Index)
+	})
+	return
+}
+
+func isIndexLess(left []int, right []int) bool {
+	for index := 0; index < len(left) && index < len(right); index++ {
+		if left[index] != right[index] {
+			return left[index] < right[index]
+		}
+	}
+	return len(left) < len(right)
+}
+
+// getFieldByIndex is like reflect.Value.FieldByIndex but it returns false if one of the embedded pointers is nil.
+func getFieldByIndex(val reflect.Value, index []int) (res reflect.Value, ok bool) {
+	res = val
+	for position, fieldIndex := range index {
+		if position > 0 && IsKindPtr(res.Kind()) {
+			if res.IsNil() {
+				res = reflect.Value{}
+				return
+			}
+			res = res.Elem()
+		}
+		res = res.Field(fieldIndex)
+	}
+	ok = true
+	return
+}
+
+func (s *Value) isFieldIncluded(field structField) bool {
+	if s.opt.SkipUnexported && field.PkgPath != "" {
+		return false
+	}
+	if s.opt.FieldTagFilter != "" {
+		if _, ok := field.Tag.Lookup(s.opt.FieldTagFilter); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Value) getFieldInfos(structVal reflect.Value) (res []FieldInfo) {
+	for _, field := range getStructFields(structVal.Type(), s.opt.FlattenEmbedded) {
+		if !s.isFieldIncluded(field) {
+			continue
+		}
+		val, ok := getFieldByIndex(structVal, field.Index)
+		if !ok {
+			continue
+		}
+		res = append(res, FieldInfo{
+			StructField: field.StructField,
+			Value:       exposeValueOpt(val, s.opt),
+			Depth:       field.depth,
+		})
+	}
+	return
+}
+
+func (s *Value) iterateEachStructField(fns []IterStructFieldFn, structVal reflect.Value, order int, info FieldInfo) (err error) {
+	for _, fn := range fns {
+		if fn == nil {
+			continue
+		}
+		err = s.handleIterError(fn(structVal, info), order, ElemError{
+			Kind:  reflect.Struct,
+			Field: info.Name,
+			Index: order,
+		})
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (s *Value) iterateStructFields(ctx context.Context, fns []IterStructFieldFn) (err error) {
+	// The addressable copy is kept local so that the s.Value still refers to the original struct.
+	structVal := getAddressableStruct(s.Value, s.opt)
+	infos := s.getFieldInfos(structVal)
+	tm := task.NewErrorManager(task.WithBufferSize(len(infos)))
+	sem := s.newWorkerLimiter()
+	for order, info := range infos {
+		order, info := order, info
+		err = s.runIteration(ctx, tm, sem, func() error {
+			return s.iterateEachStructField(fns, structVal, order, info)
+		})
+		if err != nil {
+			break
+		}
+	}
+	err = s.waitIteration(tm, err)
+	return
+}
+
+// IterateStructFields iterates the struct field using the IterStructFieldFn with the FieldInfo of each field.
+// The embedded structs are flattened by WithFlattenEmbedded, the unexported fields are skipped by WithSkipUnexported,
+// and the fields without a specific tag key are skipped by WithFieldTagFilter.
+func (s *Value) IterateStructFields(fns ...IterStructFieldFn) *Value {
+	return s.IterateStructFieldsContext(context.Background(), fns...)
+}
+
+// IterateStructFieldsContext is like IterateStructFields but it stops the iteration when the ctx is done.
+func (s *Value) IterateStructFieldsContext(ctx context.Context, fns ...IterStructFieldFn) *Value {
+	if !s.init().isStruct() {
+		return s
+	}
+
+	defer recoverFnOpt(&s.err, s.opt)
+	s.err = s.iterateStructFields(getContext(ctx), fns)
+	return s
+}
@@ -0,0 +1,146 @@
+package reflecthelper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fieldBase struct {
+	ID        int `json:"id"`
+	CreatedBy string
+}
+
+type fieldAudit struct {
+	CreatedBy string
+	UpdatedBy string `json:"updated_by"`
+}
+
+type fieldLeft struct {
+	Shared string
+}
+
+type fieldRight struct {
+	Shared string
+}
+
+type fieldUser struct {
+	fieldBase
+	*fieldAudit
+	fieldLeft
+	fieldRight
+	Name   string `json:"name"`
+	secret string
+}
+
+func collectFieldInfos(val Value) (res []FieldInfo) {
+	val.IterateStructFields(func(structInput reflect.Value, info FieldInfo) error {
+		res = append(res, info)
+		return nil
+	})
+	return
+}
+
+func getFieldInfoNames(infos []FieldInfo) (res []string) {
+	for _, info := range infos {
+		res = append(res, info.Name)
+	}
+	return
+}
+
+func TestValue_IterateStructFields(t *testing.T) {
+	user := fieldUser{
+		fieldBase:  fieldBase{ID: 1, CreatedBy: "base"},
+		fieldAudit: &fieldAudit{CreatedBy: "audit", UpdatedBy: "admin"},
+		Name:       "john",
+		secret:     "secret",
+	}
+	t.Run("kind is not struct", func(t *testing.T) {
+		val := Cast(reflect.ValueOf(1))
+		assert.Nil(t, val.IterateStructFields().Error())
+	})
+	t.Run("direct fields", func(t *testing.T) {
+		infos := collectFieldInfos(Cast(reflect.ValueOf(user)))
+		assert.Equal(t, []string{"fieldBase", "fieldAudit", "fieldLeft", "fieldRight", "Name", "secret"}, getFieldInfoNames(infos))
+		assert.True(t, infos[0].Anonymous)
+		assert.Equal(t, "name", infos[4].Tag.Get("json"))
+		assert.Equal(t, []int{4}, infos[4].Index)
+		assert.True(t, infos[4].IsExported())
+		assert.False(t, infos[5].IsExported())
+	})
+	t.Run("flatten embedded", func(t *testing.T) {
+		infos := collectFieldInfos(Cast(reflect.ValueOf(user), WithFlattenEmbedded(true)))
+		assert.Equal(t, []string{"ID", "UpdatedBy", "Name", "secret"}, getFieldInfoNames(infos))
+		assert.Equal(t, []int{0, 0}, infos[0].Index)
+		assert.Equal(t, 1, infos[0].Depth)
+		assert.Equal(t, 1, infos[0].Value.Interface())
+		assert.Equal(t, "admin", infos[1].Value.Interface())
+	})
+	t.Run("flatten embedded with nil pointer", func(t *testing.T) {
+		user := user
+		user.fieldAudit = nil
+		infos := collectFieldInfos(Cast(reflect.ValueOf(user), WithFlattenEmbedded(true)))
+		assert.Equal(t, []string{"ID", "Name", "secret"}, getFieldInfoNames(infos))
+	})
+	t.Run("skip unexported and tag filter", func(t *testing.T) {
+		infos := collectFieldInfos(Cast(reflect.ValueOf(user), WithFlattenEmbedded(true), WithSkipUnexported(true)))
+		assert.Equal(t, []string{"ID", "UpdatedBy", "Name"}, getFieldInfoNames(infos))
+
+		infos = collectFieldInfos(Cast(reflect.ValueOf(&user), WithFlattenEmbedded(true), WithFieldTagFilter("json")))
+		assert.Equal(t, []string{"ID", "UpdatedBy", "Name"}, getFieldInfoNames(infos))
+		assert.True(t, infos[2].Value.CanSet())
+	})
+	t.Run("error and concurrency", func(t *testing.T) {
+		val := Cast(reflect.ValueOf(user), WithConcurrency(true), WithCollectErrors(true), WithSkipUnexported(true))
+		val.IterateStructFields(nil, func(structInput reflect.Value, info FieldInfo) error {
+			if info.Name == "Name" {
+				return ErrTesting
+			}
+			return nil
+		})
+		assert.Equal(t, "field Name: error testing", val.Error().Error())
+	})
+	t.Run("recursive embedded type", func(t *testing.T) {
+		type node struct {
+			*node
+			Value int
+		}
+		infos := collectFieldInfos(Cast(reflect.ValueOf(node{Value: 1}), WithFlattenEmbedded(true)))
+		assert.Equal(t, []string{"Value"}, getFieldInfoNames(infos))
+	})
+}
+
+type fieldNamed struct {
+	Name string
+}
+
+type fieldNamedLeft struct {
+	fieldNamed
+}
+
+type fieldNamedRight struct {
+	fieldNamed
+}
+
+type fieldAmbiguous struct {
+	fieldNamedLeft
+	fieldNamedRight
+	ID int
+}
+
+type fieldCycle struct {
+	*fieldCycle
+	Value int
+}
+
+func TestValue_IterateStructFieldsAmbiguousEmbedding(t *testing.T) {
+	_, promoted := reflect.TypeOf(fieldAmbiguous{}).FieldByName("Name")
+	assert.False(t, promoted)
+
+	infos := collectFieldInfos(Cast(reflect.ValueOf(fieldAmbiguous{}), WithFlattenEmbedded(true)))
+	assert.Equal(t, []string{"ID"}, getFieldInfoNames(infos))
+
+	infos = collectFieldInfos(Cast(reflect.ValueOf(fieldCycle{Value: 1}), WithFlattenEmbedded(true)))
+	assert.Equal(t, []string{"Value"}, getFieldInfoNames(infos))
+}
@@ -1,10 +1,14 @@
 package reflecthelper
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -125,7 +129,7 @@ func TestValue_IterateStruct(t *testing.T) {
 			panic(errTest)
 		})
 		assert.NotNil(t, val.Error())
-		assert.Equal(t, errTest, val.Error())
+		assert.True(t, errors.Is(val.Error(), errTest))
 	})
 	t.Run("panic with recoverer - any type", func(t *testing.T) {
 		type test struct {
@@ -376,3 +380,262 @@ func TestValue_IterateChan(t *testing.T) {
 		assert.Equal(t, "error in the channel", val.Error().Error())
 	})
 }
+
+func TestValue_IterateContext(t *testing.T) {
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var called int32
+		val := Cast(reflect.ValueOf(test{"Hi!"}))
+		val.IterateStructContext(ctx, func(structInput reflect.Value, field reflect.Value) error {
+			atomic.AddInt32(&called, 1)
+			return nil
+		})
+		assert.Equal(t, context.Canceled, val.Error())
+
+		val = Cast(reflect.ValueOf([]int{1, 2, 3}), WithConcurrency(true))
+		val.IterateArraySliceContext(ctx, func(arrSliceInput reflect.Value, index int, field reflect.Value) error {
+			atomic.AddInt32(&called, 1)
+			return nil
+		})
+		assert.Equal(t, context.Canceled, val.Error())
+
+		val = Cast(reflect.ValueOf(map[string]int{"a": 1}))
+		val.IterateMapContext(ctx, func(mapInput reflect.Value, key reflect.Value, value reflect.Value) error {
+			atomic.AddInt32(&called, 1)
+			return nil
+		})
+		assert.Equal(t, context.Canceled, val.Error())
+		assert.Equal(t, int32(0), atomic.LoadInt32(&called))
+	})
+	t.Run("cancel in the middle of the iteration", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var visited []int
+		val := Cast(reflect.ValueOf([]int{1, 2, 3, 4}))
+		val.IterateArraySliceContext(ctx, func(arrSliceInput reflect.Value, index int, field reflect.Value) error {
+			visited = append(visited, index)
+			if index == 1 {
+				cancel()
+			}
+			return nil
+		})
+		assert.Equal(t, context.Canceled, val.Error())
+		assert.Equal(t, []int{0, 1}, visited)
+	})
+	t.Run("cancel after a failing element", func(t *testing.T) {
+		errElem := errors.New("error in the element")
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		val := Cast(reflect.ValueOf([]int{1, 2, 3, 4}), WithConcurrency(true), WithMaxWorkers(1))
+		val.IterateArraySliceContext(ctx, func(arrSliceInput reflect.Value, index int, field reflect.Value) error {
+			switch index {
+			case 0:
+				return errElem
+			case 1:
+				cancel()
+			}
+			return nil
+		})
+		assert.ErrorIs(t, val.Error(), errElem)
+		assert.ErrorIs(t, val.Error(), context.Canceled)
+
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+		val = Cast(reflect.ValueOf([]int{1, 2, 3, 4}), WithCollectErrors(true))
+		val.IterateArraySliceContext(ctx, func(arrSliceInput reflect.Value, index int, field reflect.Value) error {
+			switch index {
+			case 0:
+				return errElem
+			case 1:
+				cancel()
+			}
+			return nil
+		})
+		var elemErr *ElemError
+		assert.ErrorAs(t, val.Error(), &elemErr)
+		assert.Equal(t, 0, elemErr.Index)
+		assert.ErrorIs(t, val.Error(), context.Canceled)
+	})
+	t.Run("nil context", func(t *testing.T) {
+		val := Cast(reflect.ValueOf([]int{1, 2, 3}))
+		val.IterateArraySliceContext(nil, func(arrSliceInput reflect.Value, index int, field reflect.Value) error {
+			return nil
+		})
+		assert.Nil(t, val.Error())
+	})
+	t.Run("max workers", func(t *testing.T) {
+		var (
+			running    int32
+			maxRunning int32
+		)
+		list := make([]int, 20)
+		val := Cast(reflect.ValueOf(list), WithConcurrency(true), WithMaxWorkers(2))
+		val.IterateArraySlice(func(arrSliceInput reflect.Value, index int, field reflect.Value) error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				prev := atomic.LoadInt32(&maxRunning)
+				if current <= prev || atomic.CompareAndSwapInt32(&maxRunning, prev, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+		assert.Nil(t, val.Error())
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(2))
+	})
+	t.Run("blocking channel with timeout", func(t *testing.T) {
+		chanInt := make(chan int, 10)
+		chanInt <- 1
+		chanInt <- 2
+
+		var sum int
+		val := Cast(reflect.ValueOf(&chanInt), WithBlockChannel(true), WithChannelTimeout(10*time.Millisecond))
+		val.IterateChan(func(chanInput, recv reflect.Value) error {
+			sum += int(recv.Int())
+			return nil
+		})
+		assert.Equal(t, ErrChannelTimeout, val.Error())
+		assert.Equal(t, 3, sum)
+
+		close(chanInt)
+		val = Cast(reflect.ValueOf(&chanInt), WithBlockChannel(true), WithChannelTimeout(10*time.Millisecond))
+		val.IterateChan(func(chanInput, recv reflect.Value) error {
+			return nil
+		})
+		assert.Nil(t, val.Error())
+	})
+	t.Run("blocking channel with canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		chanInt := make(chan int)
+		val := Cast(reflect.ValueOf(&chanInt), WithBlockChannel(true))
+		val.IterateChanContext(ctx, func(chanInput, recv reflect.Value) error {
+			return nil
+		})
+		assert.Equal(t, context.DeadlineExceeded, val.Error())
+	})
+}
+
+func TestValue_CollectErrors(t *testing.T) {
+	t.Run("struct fields", func(t *testing.T) {
+		type test struct {
+			Name  string
+			Age   int
+			Email string
+		}
+
+		for _, concurrent := range []bool{false, true} {
+			val := Cast(reflect.ValueOf(test{}), WithCollectErrors(true), WithConcurrency(concurrent))
+			val.IterateStruct(func(structInput reflect.Value, field reflect.Value) error {
+				if field.Kind() == reflect.String {
+					return ErrTesting
+				}
+				return nil
+			})
+
+			var multiErr MultiError
+			assert.True(t, errors.As(val.Error(), &multiErr))
+			assert.Len(t, multiErr, 2)
+			assert.True(t, errors.Is(val.Error(), ErrTesting))
+
+			var elemErr *ElemError
+			assert.True(t, errors.As(val.Error(), &elemErr))
+			assert.Equal(t, "Name", elemErr.Field)
+			assert.Equal(t, "Email", multiErr[1].(*ElemError).Field)
+			assert.Equal(t, "2 errors occurred: field Name: error testing; field Email: error testing", val.Error().Error())
+		}
+	})
+	t.Run("slice indexes", func(t *testing.T) {
+		val := Cast(reflect.ValueOf([]int{1, -2, 3, -4}), WithCollectErrors(true), WithConcurrency(true))
+		val.IterateArraySlice(func(arrSliceInput reflect.Value, index int, field reflect.Value) error {
+			if field.Int() < 0 {
+				return fmt.Errorf("negative value %d", field.Int())
+			}
+			return nil
+		})
+
+		multiErr := val.Error().(MultiError)
+		assert.Len(t, multiErr, 2)
+		assert.Equal(t, 1, multiErr[0].(*ElemError).Index)
+		assert.Equal(t, 3, multiErr[1].(*ElemError).Index)
+		assert.Equal(t, "index 1: negative value -2", multiErr[0].Error())
+	})
+	t.Run("map keys", func(t *testing.T) {
+		val := Cast(reflect.ValueOf(map[string]int{"a": 1}), WithCollectErrors(true))
+		val.IterateMap(func(mapInput reflect.Value, key reflect.Value, value reflect.Value) error {
+			return ErrTesting
+		})
+		assert.Equal(t, `key "a": error testing`, val.Error().Error())
+	})
+	t.Run("channel values", func(t *testing.T) {
+		chanInt := make(chan int, 2)
+		chanInt <- 1
+		chanInt <- 2
+		val := Cast(reflect.ValueOf(&chanInt), WithCollectErrors(true))
+		val.IterateChan(func(chanInput reflect.Value, recv reflect.Value) error {
+			return ErrTesting
+		})
+		assert.Equal(t, "2 errors occurred: received value 0: error testing; received value 1: error testing", val.Error().Error())
+	})
+	t.Run("no error", func(t *testing.T) {
+		val := Cast(reflect.ValueOf([]int{1}), WithCollectErrors(true))
+		val.IterateArraySlice(func(arrSliceInput reflect.Value, index int, field reflect.Value) error {
+			return nil
+		})
+		assert.Nil(t, val.Error())
+	})
+	t.Run("ignore error has priority", func(t *testing.T) {
+		val := Cast(reflect.ValueOf([]int{1}), WithCollectErrors(true), WithIgnoreError(true))
+		val.IterateArraySlice(func(arrSliceInput reflect.Value, index int, field reflect.Value) error {
+			return ErrTesting
+		})
+		assert.Nil(t, val.Error())
+	})
+}
+
+func TestMultiError(t *testing.T) {
+	errSingle := MultiError{ErrTesting}
+	assert.Equal(t, ErrTesting.Error(), errSingle.Error())
+	assert.Equal(t, []error{ErrTesting}, errSingle.Unwrap())
+	assert.False(t, errSingle.Is(context.Canceled))
+
+	var elemErr *ElemError
+	assert.False(t, errSingle.As(&elemErr))
+}
+
+func TestValue_IterateMapSorted(t *testing.T) {
+	input := map[int]string{10: "ten", 2: "two", 33: "thirty three", 1: "one"}
+	for i := 0; i < 5; i++ {
+		var keys []int64
+		val := Cast(reflect.ValueOf(input), WithSortedMapKeys(true))
+		val.IterateMap(func(mapInput reflect.Value, key reflect.Value, value reflect.Value) error {
+			keys = append(keys, key.Int())
+			assert.Equal(t, input[int(key.Int())], value.String())
+			return nil
+		})
+		assert.Nil(t, val.Error())
+		assert.Equal(t, []int64{1, 2, 10, 33}, keys)
+	}
+}
+
+func TestValue_IterateMapNaNKey(t *testing.T) {
+	input := map[float64]int{math.NaN(): 1, 2: 2}
+	for _, sorted := range []bool{false, true} {
+		var sum int64
+		val := Cast(reflect.ValueOf(input), WithSortedMapKeys(sorted))
+		val.IterateMap(func(mapInput reflect.Value, key reflect.Value, value reflect.Value) error {
+			assert.True(t, value.IsValid())
+			sum += value.Int()
+			return nil
+		})
+		assert.Nil(t, val.Error())
+		assert.Equal(t, int64(3), sum)
+	}
+}
@@ -0,0 +1,181 @@
+package reflecthelper
+
+import "reflect"
+
+// WalkNode is a node visited by Walk.
+type WalkNode struct {
+	// Value is the value of the current node.
+	Value reflect.Value
+	// Parent is the value containing the current node. It is invalid for the root node.
+	Parent reflect.Value
+	// Field is the struct field of the current node. It is nil if the parent isn't a struct.
+	Field *reflect.StructField
+	// Path is the location of the current node from the root node.
+	// Pointer and interface targets share the same path as their parent.
+	Path Path
+	// Depth is the number of steps from the root node.
+	Depth int
+	// Cycle is true if the current node refers to one of its ancestors.
+	// The children of a cycle node are never visited.
+	Cycle bool
+}
+
+// Visitor is the contract used by Walk to visit each node.
+// Enter may return ErrSkipChildren to skip the children of the current node,
+// and both Enter and Leave may return ErrStopWalk to stop the walk without an error.
+// Leave is called after all children of the node are visited.
+type Visitor interface {
+	Enter(node *WalkNode) error
+	Leave(node *WalkNode) error
+}
+
+// VisitorFunc is a Visitor that only uses the Enter hook.
+type VisitorFunc func(node *WalkNode) error
+
+// Enter calls the fn with the node.
+func (fn VisitorFunc) Enter(node *WalkNode) error {
+	return fn(node)
+}
+
+// Leave does nothing for the VisitorFunc.
+func (fn VisitorFunc) Leave(node *WalkNode) error {
+	return nil
+}
+
+// VisitorHooks is a Visitor built from the optional OnEnter and OnLeave functions.
+type VisitorHooks struct {
+	OnEnter func(node *WalkNode) error
+	OnLeave func(node *WalkNode) error
+}
+
+// Enter calls the OnEnter hook if it is set.
+func (v VisitorHooks) Enter(node *WalkNode) error {
+	if v.OnEnter == nil {
+		return nil
+	}
+	return v.OnEnter(node)
+}
+
+// Leave calls the OnLeave hook if it is set.
+func (v VisitorHooks) Leave(node *WalkNode) error {
+	if v.OnLeave == nil {
+		return nil
+	}
+	return v.OnLeave(node)
+}
+
+type valueVisit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func getValueVisit(val reflect.Value) (res valueVisit, ok bool) {
+	switch GetKind(val) {
+	case reflect.Ptr, reflect.Map:
+		ok = !val.IsNil()
+	case reflect.Slice:
+		ok = val.Len() > 0
+	}
+	if ok {
+		res = valueVisit{val.Pointer(), val.Type()}
+	}
+	return
+}
+
+type walker struct {
+	visitor   Visitor
+	opt       *Option
+	ancestors map[valueVisit]struct{}
+}
+
+func newWalker(visitor Visitor, opt *Option) *walker {
+	return &walker{
+		visitor:   visitor,
+		opt:       opt,
+		ancestors: make(map[valueVisit]struct{}),
+	}
+}
+
+func (w *walker) walk(node *WalkNode) (err error) {
+	visit, tracked := getValueVisit(node.Value)
+	if tracked {
+		_, node.Cycle = w.ancestors[visit]
+	}
+
+	err = w.visitor.Enter(node)
+	switch err {
+	case nil:
+		if node.Cycle {
+			break
+		}
+		if tracked {
+			w.ancestors[visit] = struct{}{}
+		}
+		err = w.walkChildren(node)
+		if tracked {
+			delete(w.ancestors, visit)
+		}
+		if err != nil {
+			return
+		}
+	case ErrSkipChildren:
+		err = nil
+	default:
+		return
+	}
+
+	err = w.visitor.Leave(node)
+	if err == ErrSkipChildren {
+		err = nil
+	}
+	return
+}
+
+func (w *walker) walkChild(parent *WalkNode, child reflect.Value, field *reflect.StructField, path Path) error {
+	return w.walk(&WalkNode{
+		Value:  child,
+		Parent: parent.Value,
+		Field:  field,
+		Path:   path,
+		Depth:  parent.Depth + 1,
+	})
+}
+
+func (w *walker) walkChildren(node *WalkNode) (err error) {
+	val := node.Value
+	switch GetKind(val) {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return
+		}
+		err = w.walkChild(node, val.Elem(), nil, node.Path)
+	case reflect.Struct:
+		val = getAddressableStruct(val, w.opt)
+		typ := val.Type()
+		for index := 0; index < typ.NumField(); index++ {
+			field := typ.Field(index)
+			if field.PkgPath != "" && !w.opt.UnexportedAccess {
+				continue
+			}
+			err = w.walkChild(node, exposeValueOpt(val.Field(index), w.opt), &field, node.Path.append(PathSegment{Kind: PathField, Name: field.Name}))
+			if err != nil {
+				return
+			}
+		}
+	case reflect.Array, reflect.Slice:
+		for index := 0; index < val.Len(); index++ {
+			err = w.walkChild(node, val.Index(index), nil, node.Path.append(PathSegment{Kind: PathIndex, Index: index}))
+			if err != nil {
+				return
+			}
+		}
+	case reflect.Map:
+		for _, entry := range getMapEntries(val, w.opt) {
+			err = w.walkChild(node, entry.value, nil, node.Path.append(PathSegment{Kind: PathMapKey, Key: entry.key}))
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
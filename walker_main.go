@@ -0,0 +1,23 @@
+package reflecthelper
+
+import "reflect"
+
+// Walk visits val and all of its children recursively using the visitor.
+// The children are struct fields, slice or array elements, map entries, and pointer or interface targets.
+// Unexported struct fields are only visited if WithUnexportedAccess is used.
+// The map entries are visited in the order of their keys if WithSortedMapKeys is used.
+// Walk returns nil if the visitor stops the walk with ErrStopWalk.
+func Walk(val reflect.Value, visitor Visitor, fnOpts ...FuncOption) (err error) {
+	opt := NewOption().Assign(fnOpts...)
+	defer recoverFnOpt(&err, opt)
+
+	if !val.IsValid() || visitor == nil {
+		return
+	}
+
+	err = newWalker(visitor, opt).walk(&WalkNode{Value: val})
+	if err == ErrStopWalk {
+		err = nil
+	}
+	return
+}
@@ -0,0 +1,148 @@
+package reflecthelper
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type walkItem struct {
+	Name  string
+	Price float64
+}
+
+type walkOrder struct {
+	ID     int
+	Items  []walkItem
+	Meta   map[string]int
+	Note   interface{}
+	Parent *walkOrder
+	hidden string
+}
+
+func TestWalk(t *testing.T) {
+	newOrder := func() *walkOrder {
+		return &walkOrder{
+			ID:     1,
+			Items:  []walkItem{{Name: "apple", Price: 1.5}},
+			Meta:   map[string]int{"count": 1},
+			Note:   "fragile",
+			hidden: "hidden",
+		}
+	}
+	t.Run("invalid value or nil visitor", func(t *testing.T) {
+		assert.Nil(t, Walk(reflect.ValueOf(nil), VisitorFunc(func(node *WalkNode) error {
+			return nil
+		})))
+		assert.Nil(t, Walk(reflect.ValueOf(newOrder()), nil))
+	})
+	t.Run("visit all nodes with paths", func(t *testing.T) {
+		var paths []string
+		err := Walk(reflect.ValueOf(newOrder()), VisitorFunc(func(node *WalkNode) error {
+			if node.Field != nil {
+				assert.Equal(t, node.Path.Last().Name, node.Field.Name)
+			}
+			paths = append(paths, node.Path.String())
+			return nil
+		}))
+		assert.Nil(t, err)
+		sort.Strings(paths)
+		assert.Equal(t, []string{
+			"", "", "ID", "Items", "Items[0]", "Items[0].Name", "Items[0].Price",
+			"Meta", `Meta["count"]`, "Note", "Note", "Parent",
+		}, paths)
+	})
+	t.Run("enter and leave hooks", func(t *testing.T) {
+		var events []string
+		err := Walk(reflect.ValueOf(walkItem{Name: "a"}), VisitorHooks{
+			OnEnter: func(node *WalkNode) error {
+				events = append(events, "enter:"+node.Path.String())
+				return nil
+			},
+			OnLeave: func(node *WalkNode) error {
+				events = append(events, "leave:"+node.Path.String())
+				return nil
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"enter:", "enter:Name", "leave:Name", "enter:Price", "leave:Price", "leave:",
+		}, events)
+		assert.Nil(t, Walk(reflect.ValueOf(walkItem{}), VisitorHooks{}))
+	})
+	t.Run("skip children and stop", func(t *testing.T) {
+		var paths []string
+		err := Walk(reflect.ValueOf(newOrder()), VisitorFunc(func(node *WalkNode) error {
+			paths = append(paths, node.Path.String())
+			if node.Path.String() == "Items" {
+				return ErrSkipChildren
+			}
+			if node.Path.String() == "Meta" {
+				return ErrStopWalk
+			}
+			return nil
+		}))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"", "", "ID", "Items", "Meta"}, paths)
+	})
+	t.Run("error from the visitor", func(t *testing.T) {
+		err := Walk(reflect.ValueOf(newOrder()), VisitorHooks{
+			OnLeave: func(node *WalkNode) error {
+				if node.Path.String() == "ID" {
+					return ErrTesting
+				}
+				return nil
+			},
+		})
+		assert.True(t, errors.Is(err, ErrTesting))
+	})
+	t.Run("cycle detection", func(t *testing.T) {
+		order := newOrder()
+		order.Parent = order
+		var cycles []string
+		err := Walk(reflect.ValueOf(order), VisitorFunc(func(node *WalkNode) error {
+			if node.Cycle {
+				cycles = append(cycles, node.Path.String())
+			}
+			return nil
+		}))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Parent"}, cycles)
+	})
+	t.Run("depth and parent", func(t *testing.T) {
+		err := Walk(reflect.ValueOf(newOrder()), VisitorFunc(func(node *WalkNode) error {
+			if node.Path.String() == "Items[0].Name" {
+				assert.Equal(t, 4, node.Depth)
+				assert.Equal(t, reflect.TypeOf(walkItem{}), node.Parent.Type())
+			}
+			return nil
+		}))
+		assert.Nil(t, err)
+	})
+	t.Run("panic with recoverer", func(t *testing.T) {
+		err := Walk(reflect.ValueOf(newOrder()), VisitorFunc(func(node *WalkNode) error {
+			panic("walk panic")
+		}), WithPanicRecoverer(true))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestWalkMapNaNKey(t *testing.T) {
+	input := map[float64]int{math.NaN(): 1, 2: 2}
+	for _, sorted := range []bool{false, true} {
+		var sum int64
+		err := Walk(reflect.ValueOf(input), VisitorFunc(func(node *WalkNode) error {
+			assert.True(t, node.Value.IsValid())
+			if node.Depth > 0 {
+				sum += node.Value.Int()
+			}
+			return nil
+		}), WithSortedMapKeys(sorted))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), sum)
+	}
+}